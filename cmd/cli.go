@@ -62,6 +62,11 @@ func NewCLI() *CLI {
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			now := time.Now()
+			if cmd.Flags().Changed("seed") {
+				if seed, err := cmd.Flags().GetInt64("seed"); err == nil {
+					f.Seed = &seed
+				}
+			}
 			if q, err := cmd.Flags().GetBool("quick"); err == nil && q {
 				f.Delta = 0.01
 				f.Round = 50
@@ -116,8 +121,9 @@ func NewCLI() *CLI {
 	command.Flags().IntVarP(&f.Round, "round", "i", f.Round, "Maximum number of round before stop adjusting (number of kmeans iterations)")
 	command.Flags().Float64VarP(&f.Delta, "delta", "d", f.Delta, "Delta threshold of convergence (delta between kmeans old and new centroid’s values)")
 	command.Flags().IntVarP(&f.Concurrency, "concurrency", "t", f.Concurrency, "Maximum number image process at a time [min:1]")
-	command.Flags().StringVar(&f.DistanceAlgo, "dalgo", f.DistanceAlgo, "Distance algo for kmeans [EuclideanDistance,EuclideanDistanceSquared]")
+	command.Flags().StringVar(&f.DistanceAlgo, "dalgo", f.DistanceAlgo, "Distance algo for kmeans [EuclideanDistance,EuclideanDistanceSquared,ManhattanDistance,ChebyshevDistance,CosineDistance]")
 	command.Flags().IntVar(&f.JPEG, "jpeg", 0, "Specify quality of output jpeg compression [0-100] (set to 0 to output png)")
+	command.Flags().Int64("seed", 0, "Seed for kmeans initialization, for reproducible output (random if not set)")
 	command.PersistentFlags().Bool("debug", false, "Enable debug mode")
 	command.Flags().SortFlags = false
 	return &CLI{&command}
@@ -170,21 +176,25 @@ func handleImg(img DecodedImage, f flags) {
 		}
 	}
 
-	algo := kmeans.EuclideanDistance
-	if f.DistanceAlgo == "EuclideanDistanceSquared" {
-		algo = kmeans.EuclideanDistanceSquared
-	}
-
 	slog.Debug("Start partitioning",
 		slog.Int("cp", f.Colors),
 		slog.String("img", filepath.Base(img.Path)),
 		slog.Int("round", f.Round),
 		slog.Duration("elapsed", time.Since(now)),
 	)
-	m := kmeans.NewTrainer(f.Colors, kmeans.WithDistanceFunc(algo), kmeans.WithMaxIterations(f.Round), kmeans.WithDeltaThreshold(f.Delta)).Fit(d)
+	options := []kmeans.TrainerOption{kmeans.WithDistance(f.DistanceAlgo), kmeans.WithMaxIterations(f.Round), kmeans.WithDeltaThreshold(f.Delta)}
+	if f.Seed != nil {
+		options = append(options, kmeans.WithSeed(*f.Seed))
+	}
+	m, err := kmeans.NewTrainer(f.Colors, options...).TryFit(d)
+	if err != nil {
+		slog.Error("Error partitioning image", slog.String("img", filepath.Base(img.Path)), slog.Any("err", err))
+		return
+	}
 	rbga := image.NewRGBA(image.Rectangle{Min: image.Point{}, Max: image.Point{X: img.Width, Y: img.Height}})
+	centroids := m.Centroids()
 	for index, number := range m.Guesses() {
-		cluster := m.Cluster(number)
+		cluster := centroids[number]
 		y := index / img.Width
 		x := index % img.Width
 		if img.Type == "jpeg" {
@@ -218,7 +228,8 @@ func handleImg(img DecodedImage, f flags) {
 	slog.Info("Compress completed",
 		slog.String("out", outfile),
 		slog.Duration("took", time.Since(now)),
-		slog.Int("iter", m.Iter()))
+		slog.Int("iter", m.Iter()),
+		slog.String("stop", m.ConvergedReason().String()))
 }
 
 func round(f float64) uint8 {
@@ -234,6 +245,7 @@ type flags struct {
 	DistanceAlgo string
 	JPEG         int
 	Delta        float64
+	Seed         *int64
 }
 
 func scan(dir string) <-chan DecodedImage {
@@ -0,0 +1,79 @@
+package kmeans
+
+import (
+	"cmp"
+	"slices"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// WithMinClusterSize ensure every cluster has at least n observations.
+// After each nearest centroid assignment, the observations of clusters larger than n that are the cheapest to move
+// (smallest distance increase) are greedily moved into the undersized clusters, which is an approximation
+// of the optimal constrained assignment costing O(len(data)·log(len(data))) per undersized cluster.
+// It disables WithElkan, and fails with ErrIncompatibleOptions combined with WithMiniBatch or MacQueen.
+func WithMinClusterSize(n int) TrainerOption {
+	return func(t *Trainer) {
+		t.minSize = n
+	}
+}
+
+// balance move observations into the clusters with less than minSize observations, updating dist, cb and cn,
+// returns the number of assignments that differ from previous.
+func (t Trainer) balance(model *Model, dist []float64, cb []float64, cn Dataset, previous []int) int {
+	distanceFn, _ := compareFn(t.distanceFn)
+	sizes := make([]int, t.k)
+	for _, c := range model.mapping {
+		sizes[c]++
+	}
+
+	type move struct {
+		i       int
+		d, cost float64
+	}
+	var moves []move
+	for c := range t.k {
+		if sizes[c] >= t.minSize {
+			continue
+		}
+
+		moves = moves[:0]
+		for i, a := range model.mapping {
+			if a != c && sizes[a] > t.minSize {
+				d := distanceFn(model.data[i], model.centroids[c])
+				moves = append(moves, move{i: i, d: d, cost: d - dist[i]})
+			}
+		}
+		slices.SortFunc(moves, func(a, b move) int {
+			return cmp.Compare(a.cost, b.cost)
+		})
+
+		for _, mv := range moves {
+			if sizes[c] >= t.minSize {
+				break
+			}
+			a := model.mapping[mv.i]
+			if sizes[a] <= t.minSize {
+				// The donor shrank to the minimum during this pass.
+				continue
+			}
+			w := model.weight(mv.i)
+			cb[a] -= w
+			floats.AddScaled(cn[a], -w, model.data[mv.i])
+			cb[c] += w
+			floats.AddScaled(cn[c], w, model.data[mv.i])
+			model.mapping[mv.i] = c
+			dist[mv.i] = mv.d
+			sizes[a]--
+			sizes[c]++
+		}
+	}
+
+	changes := 0
+	for i := range previous {
+		if previous[i] != model.mapping[i] {
+			changes++
+		}
+	}
+	return changes
+}
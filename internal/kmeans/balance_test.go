@@ -0,0 +1,36 @@
+package kmeans
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMinClusterSize(t *testing.T) {
+	// A far away pair would make its own cluster of 2 observations.
+	data := append(blobs(20), []float64{100, 100}, []float64{101, 100})
+	for _, floor := range []int{5, 10, 15} {
+		m := fit(t, 4, data, WithMinClusterSize(floor))
+		for c, s := range m.Sizes() {
+			if s < floor {
+				t.Errorf("floor %d: cluster %d has %d observations, sizes %v", floor, c, s, m.Sizes())
+			}
+		}
+		if n := len(m.Guesses()); n != len(data) {
+			t.Errorf("floor %d: %d guesses for %d observations", floor, n, len(data))
+		}
+	}
+	if m := fit(t, 4, data); !slices.Contains(m.Sizes(), 2) {
+		t.Errorf("without a floor sizes %v, expected a cluster of the 2 far away observations", m.Sizes())
+	}
+}
+
+func TestMinClusterSizeErrors(t *testing.T) {
+	data := blobs(5)
+	if _, err := NewTrainer(3, WithMinClusterSize(6)).TryFit(data); !errors.Is(err, ErrInvalidClusters) {
+		t.Errorf("floor above len(data)/k: error %v, expected ErrInvalidClusters", err)
+	}
+	if _, err := NewTrainer(3, WithMinClusterSize(2), WithMiniBatch(4)).TryFit(data); !errors.Is(err, ErrIncompatibleOptions) {
+		t.Errorf("floor with mini-batch: error %v, expected ErrIncompatibleOptions", err)
+	}
+}
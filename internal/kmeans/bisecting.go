@@ -0,0 +1,154 @@
+package kmeans
+
+import (
+	"context"
+	"gonum.org/v1/gonum/floats"
+)
+
+// SplitCriterion specify which cluster is split next by bisecting kmeans.
+type SplitCriterion int
+
+const (
+	// SplitHighestSSE split the cluster with the highest sum of squared errors.
+	SplitHighestSSE SplitCriterion = iota
+	// SplitLargest split the cluster with the most observations.
+	SplitLargest
+)
+
+// BisectingTrainer train models using bisecting kmeans: starting from a single cluster,
+// it repeatedly splits one cluster in two with a 2-means run until reaching k clusters.
+type BisectingTrainer struct {
+	Trainer
+	criterion SplitCriterion
+}
+
+// NewBisectingTrainer create new BisectingTrainer, options configure each 2-means split.
+// WithInitialCentroids is ignored, and the constraints of WithConstraints are applied to each split
+// between the observations of the cluster being split.
+func NewBisectingTrainer(k int, criterion SplitCriterion, options ...TrainerOption) BisectingTrainer {
+	return BisectingTrainer{Trainer: NewTrainer(k, options...), criterion: criterion}
+}
+
+// Fit create and train the *Model.
+// It panics if the dataset or the trainer configuration is invalid, use TryFit to get an error instead.
+func (t BisectingTrainer) Fit(data Dataset) *Model {
+	m, err := t.TryFit(data)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryFit create and train the *Model, returning an error if the dataset or the trainer configuration is invalid.
+func (t BisectingTrainer) TryFit(data Dataset) (*Model, error) {
+	return t.FitContext(context.Background(), data)
+}
+
+// FitContext create and train the *Model, stopping early when ctx is done.
+// The Iter of the model is the total number of iterations of all splits.
+func (t BisectingTrainer) FitContext(ctx context.Context, data Dataset) (*Model, error) {
+	if err := t.validate(data); err != nil {
+		return nil, err
+	}
+
+	model := &Model{data: data, k: 1, distanceFn: t.distanceFn, distanceName: t.distanceName, schedule: t.schedule, mapping: make([]int, len(data))}
+	mean := make([]float64, len(data[0]))
+	for i := range data {
+		floats.Add(mean, data[i])
+	}
+	floats.Scale(1/float64(len(data)), mean)
+	model.centroids = Dataset{mean}
+
+	split := t.Trainer
+	split.k = 2
+	split.initial = nil
+	for model.k < t.k {
+		c := t.next(model)
+		if c < 0 {
+			break
+		}
+
+		var (
+			members []int
+			sub     Dataset
+		)
+		for i := range data {
+			if model.mapping[i] == c {
+				members = append(members, i)
+				sub = append(sub, data[i])
+			}
+		}
+		if split.seeded {
+			split.seed++
+		}
+		if t.mustLink != nil || t.cannotLink != nil {
+			split.mustLink, split.cannotLink = remapConstraints(members, len(data), t.mustLink), remapConstraints(members, len(data), t.cannotLink)
+		}
+		m, err := split.FitContext(ctx, sub)
+		if m != nil {
+			model.iter += m.iter
+		}
+		if err != nil {
+			model.reason = ReasonCancelled
+			model.summarize()
+			return model, err
+		}
+
+		for j, i := range members {
+			if m.mapping[j] == 1 {
+				model.mapping[i] = model.k
+			}
+		}
+		model.centroids[c] = m.centroids[0]
+		model.centroids = append(model.centroids, m.centroids[1])
+		model.k++
+	}
+
+	model.summarize()
+	if t.canonical {
+		model.canonicalize()
+	}
+	return model, nil
+}
+
+// remapConstraints returns the pairs of constraints between members, renumbered by their index in members.
+// Pairs between different clusters are dropped: must-link pairs are never split, and cannot-link pairs are satisfied.
+func remapConstraints(members []int, n int, pairs [][2]int) [][2]int {
+	index := make([]int, n)
+	for i := range index {
+		index[i] = -1
+	}
+	for j, i := range members {
+		index[i] = j
+	}
+	var remapped [][2]int
+	for _, p := range pairs {
+		if a, b := index[p[0]], index[p[1]]; a >= 0 && b >= 0 {
+			remapped = append(remapped, [2]int{a, b})
+		}
+	}
+	return remapped
+}
+
+// next returns the cluster to split according to the split criterion, or -1 if no cluster can be split.
+func (t BisectingTrainer) next(m *Model) int {
+	sizes := make([]int, m.k)
+	sse := make([]float64, m.k)
+	for i, c := range m.mapping {
+		sizes[c]++
+		sse[c] += EuclideanDistanceSquared(m.data[i], m.centroids[c])
+	}
+
+	best := -1
+	for c := range m.k {
+		if sizes[c] < 2 {
+			continue
+		}
+		if best < 0 ||
+			t.criterion == SplitLargest && sizes[c] > sizes[best] ||
+			t.criterion != SplitLargest && sse[c] > sse[best] {
+			best = c
+		}
+	}
+	return best
+}
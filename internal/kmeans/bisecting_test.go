@@ -0,0 +1,23 @@
+package kmeans
+
+import "testing"
+
+func TestBisectingConstraints(t *testing.T) {
+	data := blobs(20)
+	m, err := NewBisectingTrainer(3, SplitHighestSSE, WithSeed(1),
+		WithConstraints([][2]int{{0, 20}}, [][2]int{{1, 2}}),
+		WithInitialCentroids(Dataset{{0, 0}, {10, 5}, {20, 10}}),
+	).TryFit(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := m.Guesses(); g[0] != g[20] {
+		t.Errorf("must-link observations 0 and 20 are in clusters %d and %d", g[0], g[20])
+	}
+	if g := m.Guesses(); g[1] == g[2] {
+		t.Errorf("cannot-link observations 1 and 2 are both in cluster %d", g[1])
+	}
+	if m.k != 3 {
+		t.Errorf("k = %d, want 3", m.k)
+	}
+}
@@ -0,0 +1,44 @@
+package kmeans
+
+import "slices"
+
+// WithIterationCallback call fn after each Lloyd iteration with the number of iterations run so far,
+// counting those run before by Model.ContinueLearn,
+// the inertia of the current assignment to the updated centroids and the number of changed assignments.
+// Returning false stops the training with ReasonCallback.
+// The inertia is only computed when a callback is set. It is not called by WithMiniBatch.
+func WithIterationCallback(fn func(iter int, inertia float64, changes int) bool) TrainerOption {
+	return func(t *Trainer) {
+		t.callback = fn
+	}
+}
+
+// WithInertiaHistory record the inertia after each iteration, see Model.InertiaHistory.
+// It is not recorded by WithMiniBatch.
+func WithInertiaHistory() TrainerOption {
+	return func(t *Trainer) {
+		t.history = true
+	}
+}
+
+// observe record the iteration in the inertia history and call the iteration callback,
+// returns false if the callback asked to stop. The inertia is only computed if needed.
+func (t Trainer) observe(model *Model, iter int, changes int) bool {
+	if t.callback == nil && !t.history {
+		return true
+	}
+	inertia := model.computeInertia()
+	if t.history {
+		model.history = append(model.history, inertia)
+	}
+	return t.callback == nil || t.callback(model.iter+iter+1, inertia, changes)
+}
+
+// InertiaHistory returns a copy of the inertia after each iteration of the training, if trained WithInertiaHistory.
+// With restarts, it is the history of the kept run.
+// It should never increase, except when an empty cluster is reinitialized.
+func (m *Model) InertiaHistory() []float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.history)
+}
@@ -1,7 +1,10 @@
 package kmeans
 
 import (
+	"fmt"
 	"math"
+	"reflect"
+	"sync"
 )
 
 // DistanceFunc represents a function for measuring distance between n-dimensional vectors.
@@ -35,4 +38,269 @@
 
 		return s
 	}
+
+	// ManhattanDistance is the sum of absolute differences (L1 distance).
+	ManhattanDistance = func(a, b []float64) float64 {
+		var s float64
+
+		for i := range a {
+			s += math.Abs(a[i] - b[i])
+		}
+
+		return s
+	}
+
+	// CosineDistance is one minus the cosine similarity, it only depends on the angle between vectors.
+	// The distance involving a zero vector is defined as 1.
+	CosineDistance = func(a, b []float64) float64 {
+		var (
+			dot, na, nb float64
+		)
+
+		for i := range a {
+			dot += a[i] * b[i]
+			na += a[i] * a[i]
+			nb += b[i] * b[i]
+		}
+
+		if na == 0 || nb == 0 {
+			return 1
+		}
+		return 1 - dot/math.Sqrt(na*nb)
+	}
+
+	// ChebyshevDistance is the maximum absolute coordinate difference (L-infinity distance).
+	ChebyshevDistance = func(a, b []float64) float64 {
+		var s float64
+
+		for i := range a {
+			if t := math.Abs(a[i] - b[i]); t > s {
+				s = t
+			}
+		}
+
+		return s
+	}
+
+	// JaccardDistance is one minus the ratio between the intersection and the union of the sets of non-zero coordinates,
+	// intended for binary {0,1} feature vectors. The distance between two zero vectors is defined as 0.
+	JaccardDistance = func(a, b []float64) float64 {
+		var inter, union int
+
+		for i := range a {
+			x, y := a[i] != 0, b[i] != 0
+			if x && y {
+				inter++
+			}
+			if x || y {
+				union++
+			}
+		}
+
+		if union == 0 {
+			return 0
+		}
+		return 1 - float64(inter)/float64(union)
+	}
+
+	// CanberraDistance is the sum of |a-b|/(|a|+|b|) over dimensions, skipping dimensions where both are zero.
+	CanberraDistance = func(a, b []float64) float64 {
+		var s float64
+
+		for i := range a {
+			if d := math.Abs(a[i]) + math.Abs(b[i]); d != 0 {
+				s += math.Abs(a[i]-b[i]) / d
+			}
+		}
+
+		return s
+	}
+
+	// BrayCurtisDistance is sum|a-b| / sum|a+b|, intended for non-negative count or abundance data.
+	// When the denominator is zero, the distance is 0 for identical vectors and 1 otherwise.
+	BrayCurtisDistance = func(a, b []float64) float64 {
+		var n, d float64
+
+		for i := range a {
+			n += math.Abs(a[i] - b[i])
+			d += math.Abs(a[i] + b[i])
+		}
+
+		if d == 0 {
+			if n == 0 {
+				return 0
+			}
+			return 1
+		}
+		return n / d
+	}
+
+	// CorrelationDistance is one minus the Pearson correlation, it only depends on the shape of the vectors,
+	// not their offset or scale. The distance involving a constant vector is defined as 1.
+	CorrelationDistance = func(a, b []float64) float64 {
+		var ma, mb float64
+
+		for i := range a {
+			ma += a[i]
+			mb += b[i]
+		}
+		ma /= float64(len(a))
+		mb /= float64(len(b))
+
+		var (
+			cov, va, vb float64
+		)
+
+		for i := range a {
+			x, y := a[i]-ma, b[i]-mb
+			cov += x * y
+			va += x * x
+			vb += y * y
+		}
+
+		if va == 0 || vb == 0 {
+			return 1
+		}
+		return 1 - cov/math.Sqrt(va*vb)
+	}
+
+	// HaversineDistance is the great-circle distance in kilometers between points given as latitude and longitude in degrees.
+	// Dimensions after the first two are ignored, training rejects observations with less than two. Centroids are still the coordinate means,
+	// which is a fair approximation for clusters spanning a small area away from the antimeridian.
+	HaversineDistance = func(a, b []float64) float64 {
+		const earthRadius = 6371.0
+		lat1, lat2 := a[0]*math.Pi/180, b[0]*math.Pi/180
+		dlat := lat2 - lat1
+		dlon := (b[1] - a[1]) * math.Pi / 180
+
+		h := math.Sin(dlat/2)*math.Sin(dlat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dlon/2)*math.Sin(dlon/2)
+		return 2 * earthRadius * math.Asin(math.Sqrt(math.Min(h, 1)))
+	}
+)
+
+// MinkowskiDistance returns the generalized Lp distance, p=1 is ManhattanDistance and p=2 is EuclideanDistance.
+// For 0 < p < 1 the result is computed the same way but is not a metric as the triangle inequality does not hold.
+// It panics if p is not positive.
+func MinkowskiDistance(p float64) DistanceFunc {
+	switch {
+	case p <= 0 || math.IsNaN(p):
+		panic(fmt.Sprintf("kmeans: minkowski distance requires a positive p, got %v", p))
+	case p == 1:
+		return ManhattanDistance
+	case p == 2:
+		return EuclideanDistance
+	}
+
+	return func(a, b []float64) float64 {
+		var s float64
+
+		for i := range a {
+			s += math.Pow(math.Abs(a[i]-b[i]), p)
+		}
+
+		return math.Pow(s, 1/p)
+	}
+}
+
+// WeightedEuclideanDistance returns the euclidean distance where each squared coordinate difference is scaled by weights.
+// The returned function panics if the vectors length does not match len(weights).
+func WeightedEuclideanDistance(weights []float64) DistanceFunc {
+	w := append([]float64(nil), weights...)
+	return func(a, b []float64) float64 {
+		if len(a) != len(w) {
+			panic(fmt.Sprintf("kmeans: weighted euclidean distance expects %d dimensions, got %d", len(w), len(a)))
+		}
+
+		var (
+			s, t float64
+		)
+
+		for i := range a {
+			t = a[i] - b[i]
+			s += w[i] * t * t
+		}
+
+		return math.Sqrt(s)
+	}
+}
+
+// compareFn returns a distance ordering vectors like fn but cheaper to compute, for nearest centroid searches,
+// and whether it is the square of fn.
+func compareFn(fn DistanceFunc) (DistanceFunc, bool) {
+	if reflect.ValueOf(fn).Pointer() == reflect.ValueOf(EuclideanDistance).Pointer() {
+		return EuclideanDistanceSquared, true
+	}
+	return fn, false
+}
+
+type registeredDistance struct {
+	fn DistanceFunc
+	// metric tells whether fn satisfies the triangle inequality.
+	metric bool
+	// builtin tells whether fn is one of the package distances.
+	builtin bool
+}
+
+var (
+	distancesMu sync.RWMutex
+	distances   = map[string]registeredDistance{
+		"EuclideanDistance":        {EuclideanDistance, true, true},
+		"EuclideanDistanceSquared": {EuclideanDistanceSquared, false, true},
+		"ManhattanDistance":        {ManhattanDistance, true, true},
+		"ChebyshevDistance":        {ChebyshevDistance, true, true},
+		"CosineDistance":           {CosineDistance, false, true},
+		"JaccardDistance":          {JaccardDistance, true, true},
+		"CanberraDistance":         {CanberraDistance, true, true},
+		"BrayCurtisDistance":       {BrayCurtisDistance, false, true},
+		"CorrelationDistance":      {CorrelationDistance, false, true},
+		"HaversineDistance":        {HaversineDistance, true, true},
+		"DTWDistance":              {DTWDistance, false, true},
+	}
 )
+
+// RegisterDistance register fn under name, so models using it can be trained with WithDistance and serialized.
+// Registering an existing name replaces the previous function.
+func RegisterDistance(name string, fn DistanceFunc) {
+	distancesMu.Lock()
+	defer distancesMu.Unlock()
+	distances[name] = registeredDistance{fn: fn}
+}
+
+// RegisterMetric register fn under name like RegisterDistance, declaring that it is a true metric
+// (satisfying the triangle inequality), which enables accelerations like WithElkan.
+func RegisterMetric(name string, fn DistanceFunc) {
+	distancesMu.Lock()
+	defer distancesMu.Unlock()
+	distances[name] = registeredDistance{fn: fn, metric: true}
+}
+
+// LookupDistance returns the distance function registered under name.
+func LookupDistance(name string) (DistanceFunc, bool) {
+	distancesMu.RLock()
+	defer distancesMu.RUnlock()
+	d, ok := distances[name]
+	return d.fn, ok
+}
+
+// distanceName returns the name under which the built-in distance fn is registered, or "" if fn is not built-in.
+// Only built-in distances are resolved, as closures returned by the same function share their code pointer.
+func distanceName(fn DistanceFunc) string {
+	if fn == nil {
+		return ""
+	}
+	p := reflect.ValueOf(fn).Pointer()
+	distancesMu.RLock()
+	defer distancesMu.RUnlock()
+	for name, d := range distances {
+		if d.builtin && reflect.ValueOf(d.fn).Pointer() == p {
+			return name
+		}
+	}
+	return ""
+}
+
+func isMetric(name string) bool {
+	distancesMu.RLock()
+	defer distancesMu.RUnlock()
+	return distances[name].metric
+}
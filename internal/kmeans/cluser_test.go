@@ -0,0 +1,96 @@
+package kmeans
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestManhattanDistance(t *testing.T) {
+	tests := []struct {
+		a, b []float64
+		want float64
+	}{
+		{[]float64{0}, []float64{0}, 0},
+		{[]float64{1}, []float64{-2}, 3},
+		{[]float64{1, 2}, []float64{4, 6}, 7},
+		{[]float64{-1, 0, 3}, []float64{1, 1, -1}, 7},
+	}
+	for _, tt := range tests {
+		if got := ManhattanDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("ManhattanDistance(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMinkowskiDistance(t *testing.T) {
+	a, b := []float64{1, 2, 3}, []float64{4, 0, 3}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{1, ManhattanDistance(a, b)},
+		{2, EuclideanDistance(a, b)},
+		{3, math.Cbrt(27 + 8)},
+	}
+	for _, tt := range tests {
+		if got := MinkowskiDistance(tt.p)(a, b); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("MinkowskiDistance(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestMinkowskiDistanceInvalidP(t *testing.T) {
+	for _, p := range []float64{0, -1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("MinkowskiDistance(%v) did not panic", p)
+				}
+			}()
+			MinkowskiDistance(p)
+		}()
+	}
+}
+
+func TestChebyshevDistance(t *testing.T) {
+	tests := []struct {
+		a, b []float64
+		want float64
+	}{
+		{[]float64{1}, []float64{-2}, 3},
+		{[]float64{1, 2}, []float64{4, 6}, 4},
+		{[]float64{-1, 0, 3}, []float64{1, 1, -3}, 6},
+		{[]float64{0, 0, 0, 0}, []float64{1, -5, 2, 0.5}, 5},
+	}
+	for _, tt := range tests {
+		if got := ChebyshevDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("ChebyshevDistance(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestHaversineDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{"Paris-London", []float64{48.8566, 2.3522}, []float64{51.5074, -0.1278}, 343.5},
+		{"New York-Los Angeles", []float64{40.7128, -74.0060}, []float64{34.0522, -118.2437}, 3935.7},
+		{"same point", []float64{10, 20, 99}, []float64{10, 20, -99}, 0},
+	}
+	for _, tt := range tests {
+		if got := HaversineDistance(tt.a, tt.b); math.Abs(got-tt.want) > 1 {
+			t.Errorf("%s: HaversineDistance() = %v km, want %v km", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHaversineDistanceRequiresTwoDimensions(t *testing.T) {
+	for _, option := range []TrainerOption{WithDistance("HaversineDistance"), WithDistanceFunc(HaversineDistance)} {
+		if _, err := NewTrainer(1, option).TryFit(Dataset{{1}, {2}}); !errors.Is(err, ErrDimensionMismatch) {
+			t.Errorf("TryFit() error = %v, want %v", err, ErrDimensionMismatch)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ClusterStat describes the spread of a cluster over the training data.
+type ClusterStat struct {
+	// Size is the number of observations assigned to the cluster.
+	Size int
+	// MeanDistance is the mean distance between the observations and the centroid, weighted if trained with weights.
+	MeanDistance float64
+	// MaxDistance is the largest distance between an observation and the centroid.
+	MaxDistance float64
+}
+
+// ClusterStats returns the spread of each cluster, indexed by cluster.
+// It returns nil for models without training data, such as loaded ones.
+func (m *Model) ClusterStats() []ClusterStat {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.mapping) == 0 {
+		return nil
+	}
+
+	stats := make([]ClusterStat, m.k)
+	total := make([]float64, m.k)
+	for i, c := range m.mapping {
+		d := m.distanceFn(m.data[i], m.centroids[c])
+		stats[c].Size++
+		stats[c].MeanDistance += m.weight(i) * d
+		stats[c].MaxDistance = math.Max(stats[c].MaxDistance, d)
+		total[c] += m.weight(i)
+	}
+	for c := range stats {
+		if total[c] > 0 {
+			stats[c].MeanDistance /= total[c]
+		}
+	}
+	return stats
+}
+
+// ClusterIndices returns the indices in the training dataset of the observations assigned to cluster.
+func (m *Model) ClusterIndices(cluster int) []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.clusterIndices(cluster)
+}
+
+// clusterIndices is ClusterIndices without locking.
+func (m *Model) clusterIndices(cluster int) []int {
+	var indices []int
+	for i, c := range m.mapping {
+		if c == cluster {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// ClusterPoints returns a copy of the training observations assigned to cluster,
+// normalized if the model was trained with WithSpherical.
+func (m *Model) ClusterPoints(cluster int) Dataset {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	indices := m.clusterIndices(cluster)
+	points := make(Dataset, len(indices))
+	for i, p := range indices {
+		points[i] = slices.Clone(m.data[p])
+	}
+	return points
+}
+
+// ClusterEntropy returns the Shannon entropy (in nats) of the distribution of the training observations among clusters,
+// log(k) for perfectly balanced clusters and 0 when a single cluster holds every observation.
+func (m *Model) ClusterEntropy() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	n := 0
+	for _, s := range m.sizes {
+		n += s
+	}
+	h := float64(0)
+	for _, s := range m.sizes {
+		if s > 0 {
+			p := float64(s) / float64(n)
+			h -= p * math.Log(p)
+		}
+	}
+	return h
+}
+
+// SplitCluster splits cluster in two with a 2-means run on its training observations, configured by options,
+// without retraining the other clusters. The cluster keeps its number for the first half
+// and the second half becomes cluster k, so the other labels are unchanged.
+// It returns ErrNotFitted for models without training data, such as loaded ones.
+func (m *Model) SplitCluster(cluster int, options ...TrainerOption) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.mapping) == 0 {
+		return ErrNotFitted
+	}
+	if cluster < 0 || cluster >= m.k {
+		return fmt.Errorf("%w: cluster %d out of [0, %d)", ErrInvalidClusters, cluster, m.k)
+	}
+
+	var (
+		members []int
+		sub     Dataset
+		weights []float64
+	)
+	for i, c := range m.mapping {
+		if c == cluster {
+			members = append(members, i)
+			sub = append(sub, m.data[i])
+			weights = append(weights, m.weight(i))
+		}
+	}
+	if len(members) < 2 {
+		return fmt.Errorf("%w: cluster %d has %d observations, at least 2 are needed to split it", ErrInvalidClusters, cluster, len(members))
+	}
+
+	t := NewTrainer(2, options...)
+	t.distanceFn = m.distanceFn
+	t.distanceName = m.distanceName
+	var (
+		split *Model
+		err   error
+	)
+	if m.weights == nil {
+		split, err = t.TryFit(sub)
+	} else {
+		split, err = t.FitWeighted(sub, weights)
+	}
+	if err != nil {
+		return err
+	}
+
+	for j, i := range members {
+		if split.mapping[j] == 1 {
+			m.mapping[i] = m.k
+		}
+	}
+	m.centroids[cluster] = split.centroids[0]
+	m.centroids = append(m.centroids, split.centroids[1])
+	m.k++
+	m.summarize()
+	if m.counts != nil {
+		m.counts[cluster] = m.sizes[cluster]
+		m.counts = append(m.counts, m.sizes[m.k-1])
+	}
+	return nil
+}
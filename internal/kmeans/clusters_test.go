@@ -0,0 +1,38 @@
+package kmeans
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitCluster(t *testing.T) {
+	data := blobs(30)
+	// A single iteration from these centroids assigns the first two blobs to cluster 0, which is split back in two.
+	m := fit(t, 2, data, WithInitialCentroids(Dataset{{5, 2.5}, {20, 10}}), WithMaxIterations(1))
+	before := m.Guesses()
+	broad := slices.Index(m.Sizes(), 60)
+	if broad < 0 {
+		t.Fatalf("sizes %v, expected a cluster of two blobs", m.Sizes())
+	}
+	if err := m.SplitCluster(broad, WithSeed(1)); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Sizes(); len(got) != 3 || got[0] != 30 || got[1] != 30 || got[2] != 30 {
+		t.Errorf("sizes after split %v, expected 30 each", got)
+	}
+	truth := make([]int, len(data))
+	for i := range truth {
+		truth[i] = i / 30
+	}
+	if ari, _ := AdjustedRandIndex(m.Guesses(), truth); ari != 1 {
+		t.Errorf("adjusted rand index after split %v, expected 1", ari)
+	}
+	for i, c := range before {
+		if c != broad && m.Guesses()[i] != c {
+			t.Fatalf("observation %d moved from cluster %d to %d", i, c, m.Guesses()[i])
+		}
+	}
+	if err := m.SplitCluster(3); err == nil {
+		t.Error("splitting a cluster out of range succeeded")
+	}
+}
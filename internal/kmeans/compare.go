@@ -0,0 +1,142 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// contingency is the contingency table of two labelings along with its marginals.
+type contingency struct {
+	n     int
+	cells map[[2]int]int
+	a     map[int]int
+	b     map[int]int
+}
+
+func newContingency(labelsA, labelsB []int) (contingency, error) {
+	if len(labelsA) != len(labelsB) {
+		return contingency{}, fmt.Errorf("%w: got %d and %d labels", ErrLabelsMismatch, len(labelsA), len(labelsB))
+	}
+	c := contingency{n: len(labelsA), cells: make(map[[2]int]int), a: make(map[int]int), b: make(map[int]int)}
+	for i := range labelsA {
+		c.cells[[2]int{labelsA[i], labelsB[i]}]++
+		c.a[labelsA[i]]++
+		c.b[labelsB[i]]++
+	}
+	return c, nil
+}
+
+// AdjustedRandIndex returns the Rand index of two labelings of the same observations adjusted for chance,
+// 1 for identical partitions (up to renumbering) and around 0 for independent ones.
+func AdjustedRandIndex(labelsA, labelsB []int) (float64, error) {
+	c, err := newContingency(labelsA, labelsB)
+	if err != nil {
+		return 0, err
+	}
+	pairs := func(n int) float64 {
+		return float64(n) * float64(n-1) / 2
+	}
+
+	index := float64(0)
+	for _, n := range c.cells {
+		index += pairs(n)
+	}
+	sa, sb := float64(0), float64(0)
+	for _, n := range c.a {
+		sa += pairs(n)
+	}
+	for _, n := range c.b {
+		sb += pairs(n)
+	}
+
+	if c.n < 2 {
+		return 1, nil
+	}
+	expected := sa * sb / pairs(c.n)
+	maximum := (sa + sb) / 2
+	if maximum == expected {
+		// Both labelings are a single cluster or only singletons.
+		return 1, nil
+	}
+	return (index - expected) / (maximum - expected), nil
+}
+
+// EntropyAverage specify how NormalizedMutualInformation combines the entropies of the two labelings.
+type EntropyAverage int
+
+const (
+	// AverageArithmetic normalize by the arithmetic mean of the entropies, also known as the V-measure.
+	AverageArithmetic EntropyAverage = iota
+	// AverageGeometric normalize by the geometric mean of the entropies.
+	AverageGeometric
+	// AverageMin normalize by the smallest entropy.
+	AverageMin
+	// AverageMax normalize by the largest entropy.
+	AverageMax
+)
+
+// NormalizedMutualInformation returns the mutual information of two labelings of the same observations
+// normalized by the given average of their entropies, in [0, 1] where 1 means identical partitions (up to renumbering).
+func NormalizedMutualInformation(labelsA, labelsB []int, average EntropyAverage) (float64, error) {
+	c, err := newContingency(labelsA, labelsB)
+	if err != nil {
+		return 0, err
+	}
+	n := float64(c.n)
+	entropy := func(marginal map[int]int) float64 {
+		h := float64(0)
+		for _, m := range marginal {
+			p := float64(m) / n
+			h -= p * math.Log(p)
+		}
+		return h
+	}
+
+	ha, hb := entropy(c.a), entropy(c.b)
+	if ha == 0 && hb == 0 {
+		// Both labelings are a single cluster, or there is no observation.
+		return 1, nil
+	}
+	mi := float64(0)
+	for cell, m := range c.cells {
+		nij := float64(m)
+		mi += nij / n * math.Log(n*nij/(float64(c.a[cell[0]])*float64(c.b[cell[1]])))
+	}
+
+	var norm float64
+	switch average {
+	case AverageGeometric:
+		norm = math.Sqrt(ha * hb)
+	case AverageMin:
+		norm = math.Min(ha, hb)
+	case AverageMax:
+		norm = math.Max(ha, hb)
+	default:
+		norm = (ha + hb) / 2
+	}
+	if norm == 0 {
+		return 0, nil
+	}
+	return math.Max(mi, 0) / norm, nil
+}
+
+// Purity returns the fraction of observations whose true label is the most common true label of their predicted cluster,
+// in (0, 1] where 1 means every cluster only holds one true label. It is 1 when there is no observation.
+func Purity(predicted, truth []int) (float64, error) {
+	c, err := newContingency(predicted, truth)
+	if err != nil {
+		return 0, err
+	}
+	if c.n == 0 {
+		return 1, nil
+	}
+	majority := make(map[int]int, len(c.a))
+	for cell, n := range c.cells {
+		majority[cell[0]] = max(majority[cell[0]], n)
+	}
+	s := 0
+	for _, n := range majority {
+		s += n
+	}
+	return float64(s) / float64(c.n), nil
+}
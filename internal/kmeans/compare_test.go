@@ -0,0 +1,42 @@
+package kmeans
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNormalizedMutualInformation(t *testing.T) {
+	// Hand-computed for a = [0 0 1 1] and b = [0 0 0 1].
+	mi := 0.5*math.Log(4.0/3) + 0.25*math.Log(2.0/3) + 0.25*math.Log(2)
+	ha, hb := math.Log(2), -(0.75*math.Log(0.75) + 0.25*math.Log(0.25))
+	tests := []struct {
+		name    string
+		a, b    []int
+		average EntropyAverage
+		want    float64
+	}{
+		{"arithmetic", []int{0, 0, 1, 1}, []int{0, 0, 0, 1}, AverageArithmetic, mi / ((ha + hb) / 2)},
+		{"geometric", []int{0, 0, 1, 1}, []int{0, 0, 0, 1}, AverageGeometric, mi / math.Sqrt(ha*hb)},
+		{"min", []int{0, 0, 1, 1}, []int{0, 0, 0, 1}, AverageMin, mi / hb},
+		{"max", []int{0, 0, 1, 1}, []int{0, 0, 0, 1}, AverageMax, mi / ha},
+		{"renumbered", []int{0, 0, 1, 1, 2}, []int{5, 5, 3, 3, 9}, AverageArithmetic, 1},
+		{"independent", []int{0, 0, 1, 1}, []int{0, 1, 0, 1}, AverageArithmetic, 0},
+		{"single clusters", []int{0, 0, 0}, []int{1, 1, 1}, AverageArithmetic, 1},
+	}
+	for _, tt := range tests {
+		got, err := NormalizedMutualInformation(tt.a, tt.b, tt.average)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s: NormalizedMutualInformation() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizedMutualInformationMismatch(t *testing.T) {
+	if _, err := NormalizedMutualInformation([]int{0}, []int{0, 1}, AverageArithmetic); !errors.Is(err, ErrLabelsMismatch) {
+		t.Errorf("NormalizedMutualInformation() error = %v, want %v", err, ErrLabelsMismatch)
+	}
+}
@@ -0,0 +1,133 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// WithConstraints train using COP-kmeans (Wagstaff et al., 2001): observations of each must-link pair always share a cluster,
+// and observations of each cannot-link pair never do. Pairs are indices in the training dataset.
+// Must-link pairs are merged transitively into groups, each group is assigned as a whole to the nearest centroid
+// not used by a group it cannot be linked with, in dataset order.
+// Training fails with ErrUnsatisfiable when a group has no such centroid.
+// It disables WithElkan and WithMinClusterSize, and fails with ErrIncompatibleOptions combined with WithMiniBatch or MacQueen.
+func WithConstraints(mustLink, cannotLink [][2]int) TrainerOption {
+	return func(t *Trainer) {
+		t.mustLink = mustLink
+		t.cannotLink = cannotLink
+	}
+}
+
+// constraints are the must-link groups and the cannot-link relations between them.
+type constraints struct {
+	groups [][]int
+	cannot [][]int
+	// clusters is the cluster of each group during an assignment.
+	clusters []int
+}
+
+func newConstraints(n int, mustLink, cannotLink [][2]int) (*constraints, error) {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	valid := func(p [2]int) bool {
+		return p[0] >= 0 && p[0] < n && p[1] >= 0 && p[1] < n
+	}
+
+	for _, p := range mustLink {
+		if !valid(p) {
+			return nil, fmt.Errorf("%w: must-link pair %v out of range for %d observations", ErrInvalidConstraints, p, n)
+		}
+		parent[find(p[0])] = find(p[1])
+	}
+
+	c := &constraints{}
+	group := make([]int, n)
+	roots := make(map[int]int)
+	for i := range n {
+		g, ok := roots[find(i)]
+		if !ok {
+			g = len(c.groups)
+			roots[find(i)] = g
+			c.groups = append(c.groups, nil)
+		}
+		group[i] = g
+		c.groups[g] = append(c.groups[g], i)
+	}
+
+	c.cannot = make([][]int, len(c.groups))
+	for _, p := range cannotLink {
+		if !valid(p) {
+			return nil, fmt.Errorf("%w: cannot-link pair %v out of range for %d observations", ErrInvalidConstraints, p, n)
+		}
+		a, b := group[p[0]], group[p[1]]
+		if a == b {
+			return nil, fmt.Errorf("%w: observations %d and %d are both must-linked and cannot-linked", ErrUnsatisfiable, p[0], p[1])
+		}
+		c.cannot[a] = append(c.cannot[a], b)
+		c.cannot[b] = append(c.cannot[b], a)
+	}
+	c.clusters = make([]int, len(c.groups))
+	return c, nil
+}
+
+// constrainedAssign is the equivalent of Trainer.assign respecting the constraints.
+func (t Trainer) constrainedAssign(model *Model, dist []float64, cb []float64, cn Dataset, c *constraints) (int, error) {
+	distanceFn, _ := compareFn(t.distanceFn)
+	cost := make([]float64, t.k)
+	order := make([]int, t.k)
+	for i := range c.clusters {
+		c.clusters[i] = -1
+	}
+
+	changes := 0
+	for g, members := range c.groups {
+		clear(cost)
+		for _, i := range members {
+			for j := range t.k {
+				cost[j] += model.weight(i) * distanceFn(model.data[i], model.centroids[j])
+			}
+		}
+		for j := range order {
+			order[j] = j
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(cost[a], cost[b])
+		})
+
+		n := -1
+		for _, j := range order {
+			if !slices.ContainsFunc(c.cannot[g], func(o int) bool { return c.clusters[o] == j }) {
+				n = j
+				break
+			}
+		}
+		if n < 0 {
+			return changes, fmt.Errorf("%w: no cluster left for observation %d", ErrUnsatisfiable, members[0])
+		}
+
+		c.clusters[g] = n
+		for _, i := range members {
+			if model.mapping[i] != n {
+				changes++
+			}
+			model.mapping[i] = n
+			dist[i] = distanceFn(model.data[i], model.centroids[n])
+			w := model.weight(i)
+			cb[n] += w
+			floats.AddScaled(cn[n], w, model.data[i])
+		}
+	}
+	return changes, nil
+}
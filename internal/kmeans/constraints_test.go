@@ -0,0 +1,33 @@
+package kmeans
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConstraints(t *testing.T) {
+	data := Dataset{{0}, {0.1}, {10}, {10.1}}
+	m := fit(t, 2, data, WithConstraints([][2]int{{0, 2}}, [][2]int{{0, 1}}))
+	guesses := m.Guesses()
+	if guesses[0] != guesses[2] {
+		t.Errorf("must-link pair split: %v", guesses)
+	}
+	if guesses[0] == guesses[1] {
+		t.Errorf("cannot-link pair together: %v", guesses)
+	}
+}
+
+func TestConstraintsErrors(t *testing.T) {
+	data := Dataset{{0}, {1}, {2}, {3}}
+	triangle := WithConstraints(nil, [][2]int{{0, 1}, {1, 2}, {0, 2}})
+	if _, err := NewTrainer(2, triangle).TryFit(data); !errors.Is(err, ErrUnsatisfiable) {
+		t.Errorf("three mutually cannot-linked observations in 2 clusters: error %v, expected ErrUnsatisfiable", err)
+	}
+	if _, err := NewTrainer(2, WithConstraints([][2]int{{0, 4}}, nil)).TryFit(data); !errors.Is(err, ErrInvalidConstraints) {
+		t.Errorf("constraint out of the dataset: error %v, expected ErrInvalidConstraints", err)
+	}
+	constraints := WithConstraints([][2]int{{0, 3}}, [][2]int{{0, 1}})
+	if _, err := NewTrainer(2, constraints, WithMiniBatch(2)).TryFit(data); !errors.Is(err, ErrIncompatibleOptions) {
+		t.Errorf("constraints with mini-batch: error %v, expected ErrIncompatibleOptions", err)
+	}
+}
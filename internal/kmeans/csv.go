@@ -0,0 +1,69 @@
+package kmeans
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVOptions configure LoadCSV.
+type CSVOptions struct {
+	// SkipHeader ignore the first record.
+	SkipHeader bool
+	// Columns select the columns to use as dimensions, by index, default to all columns.
+	Columns []int
+}
+
+// LoadCSV parse the numeric records of r into a Dataset, one observation per record.
+func LoadCSV(r io.Reader, opts CSVOptions) (Dataset, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	reader.TrimLeadingSpace = true
+
+	var data Dataset
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return data, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("kmeans: read csv: %w", err)
+		}
+		if line == 1 && opts.SkipHeader {
+			continue
+		}
+
+		columns := opts.Columns
+		if columns == nil {
+			columns = make([]int, len(record))
+			for j := range columns {
+				columns[j] = j
+			}
+		}
+		p := make([]float64, len(columns))
+		for j, c := range columns {
+			if c < 0 || c >= len(record) {
+				return nil, fmt.Errorf("%w: record %d has no column %d", ErrDimensionMismatch, line, c)
+			}
+			if p[j], err = strconv.ParseFloat(strings.TrimSpace(record[c]), 64); err != nil {
+				return nil, fmt.Errorf("kmeans: record %d column %d: %w", line, c, err)
+			}
+		}
+		data = append(data, p)
+	}
+}
+
+// WriteLabels write one cluster label per line to w.
+func WriteLabels(w io.Writer, labels []int) error {
+	bw := bufio.NewWriter(w)
+	for _, l := range labels {
+		if _, err := bw.WriteString(strconv.Itoa(l) + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
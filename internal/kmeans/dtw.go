@@ -0,0 +1,130 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// DTWDistance is the dynamic time warping distance between two series, the euclidean distance along the
+// warping path aligning them best, in O(len(a)·len(b)).
+// Averaging series is not meaningful under warping, so pair it with NewMedoidsTrainer, or with WithDBAUpdate.
+var DTWDistance = func(a, b []float64) float64 {
+	return math.Sqrt(dtwLast(dtw(a, b, -1)))
+}
+
+// DTWBandDistance returns the dynamic time warping distance constrained to a Sakoe-Chiba band,
+// where the i-th element of a can only be aligned with the elements of b within window positions,
+// costing O(len(a)·window). It panics if window is negative.
+func DTWBandDistance(window int) DistanceFunc {
+	if window < 0 {
+		panic(fmt.Sprintf("kmeans: dtw band requires a non-negative window, got %d", window))
+	}
+	return func(a, b []float64) float64 {
+		return math.Sqrt(dtwLast(dtw(a, b, window)))
+	}
+}
+
+// dtw returns the (len(a)+1)×(len(b)+1) row-major cumulative cost matrix of aligning a and b,
+// using squared differences and a band of window positions, or no band if window is negative.
+// Cells out of the band are +Inf. The last cell is the squared DTW distance.
+func dtw(a, b []float64, window int) []float64 {
+	n, m := len(a), len(b)
+	if window >= 0 {
+		// The band must be wide enough to reach the last cell.
+		window = max(window, abs(n-m))
+	}
+	width := m + 1
+	cost := make([]float64, (n+1)*width)
+	for i := range cost {
+		cost[i] = math.Inf(1)
+	}
+	cost[0] = 0
+
+	for i := 1; i <= n; i++ {
+		from, to := 1, m
+		if window >= 0 {
+			from, to = max(1, i-window), min(m, i+window)
+		}
+		for j := from; j <= to; j++ {
+			d := a[i-1] - b[j-1]
+			cost[i*width+j] = d*d + min(cost[(i-1)*width+j], cost[i*width+j-1], cost[(i-1)*width+j-1])
+		}
+	}
+	return cost
+}
+
+// dtwLast returns the squared DTW distance from a cumulative cost matrix.
+func dtwLast(cost []float64) float64 {
+	return cost[len(cost)-1]
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// WithDBAUpdate train using DTW barycenter averaging (Petitjean et al., 2011): each centroid is updated by
+// iterations refinement passes, each aligning every series of its cluster to the centroid with DTW
+// and averaging the values aligned with each centroid position, since arithmetic means are meaningless under warping.
+// It pairs with DTWDistance, alignments are not banded.
+// Each training iteration costs O(iterations·len(data)·L²) for series of length L, on top of the assignment.
+func WithDBAUpdate(iterations int) TrainerOption {
+	return func(t *Trainer) {
+		t.dba = iterations
+	}
+}
+
+// updateDBA refine each non-empty cluster centroid toward the DTW barycenter of its observations.
+func (t Trainer) updateDBA(m *Model) {
+	members := make([][]int, m.k)
+	for i, c := range m.mapping {
+		members[c] = append(members[c], i)
+	}
+	runChunks(t.workers(m.k), m.k, func(_, from, to int) {
+		for c := from; c < to; c++ {
+			if len(members[c]) == 0 {
+				continue
+			}
+			centroid := m.centroids[c]
+			sums := make([]float64, len(centroid))
+			weights := make([]float64, len(centroid))
+			for range t.dba {
+				clear(sums)
+				clear(weights)
+				for _, i := range members[c] {
+					w := m.weight(i)
+					align(centroid, m.data[i], func(p, q int) {
+						sums[p] += w * m.data[i][q]
+						weights[p] += w
+					})
+				}
+				for p := range centroid {
+					if weights[p] > 0 {
+						centroid[p] = sums[p] / weights[p]
+					}
+				}
+			}
+		}
+	})
+}
+
+// align call fn for each pair of positions (i, j) of the optimal DTW warping path between a and b.
+func align(a, b []float64, fn func(i, j int)) {
+	cost := dtw(a, b, -1)
+	width := len(b) + 1
+	i, j := len(a), len(b)
+	for i > 0 && j > 0 {
+		fn(i-1, j-1)
+		diag, up, left := cost[(i-1)*width+j-1], cost[(i-1)*width+j], cost[i*width+j-1]
+		switch {
+		case diag <= up && diag <= left:
+			i, j = i-1, j-1
+		case up <= left:
+			i--
+		default:
+			j--
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+)
+
+// sine returns n samples of a sine wave with the given period, shifted by shift samples.
+func sine(n int, period float64, shift int) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = math.Sin(2 * math.Pi * float64(i+shift) / period)
+	}
+	return s
+}
+
+func TestDTWDistanceShiftedSine(t *testing.T) {
+	a, b := sine(100, 25, 0), sine(100, 25, 3)
+	if d := DTWDistance(a, a); d != 0 {
+		t.Errorf("DTW of a series to itself %v, expected 0", d)
+	}
+	dtw, euclidean := DTWDistance(a, b), EuclideanDistance(a, b)
+	if dtw > euclidean/3 {
+		t.Errorf("DTW of shifted sine waves %v, expected much less than euclidean %v", dtw, euclidean)
+	}
+	if DTWDistance(b, a) != dtw {
+		t.Errorf("DTW is not symmetric: %v and %v", DTWDistance(b, a), dtw)
+	}
+	// A sine of another period cannot be warped onto it as closely.
+	if other := DTWDistance(a, sine(100, 50, 0)); other <= dtw {
+		t.Errorf("DTW to another period %v, expected more than %v", other, dtw)
+	}
+}
+
+func TestDTWDistanceDifferentLengths(t *testing.T) {
+	if d := DTWDistance([]float64{0, 1, 2}, []float64{0, 0, 1, 1, 2}); d != 0 {
+		t.Errorf("DTW of a series to its stretched copy %v, expected 0", d)
+	}
+	// The extra 3 can only be aligned with the last 2.
+	if d := DTWDistance([]float64{0, 1, 2}, []float64{0, 1, 2, 3}); d != 1 {
+		t.Errorf("DTW %v, expected 1", d)
+	}
+}
+
+func TestDTWBandDistance(t *testing.T) {
+	a, b := sine(100, 25, 0), sine(100, 25, 3)
+	if d, e := DTWBandDistance(0)(a, b), EuclideanDistance(a, b); math.Abs(d-e) > 1e-12 {
+		t.Errorf("DTW with a zero band %v, expected the euclidean distance %v", d, e)
+	}
+	if d, e := DTWBandDistance(len(a))(a, b), DTWDistance(a, b); d != e {
+		t.Errorf("DTW with a full band %v, expected the unbanded distance %v", d, e)
+	}
+	previous := math.Inf(1)
+	for _, window := range []int{0, 1, 2, 3, 5, 10} {
+		d := DTWBandDistance(window)(a, b)
+		if d > previous {
+			t.Errorf("DTW with window %d is %v, expected at most %v from the narrower band", window, d, previous)
+		}
+		previous = d
+	}
+	// The band is widened to reach the last cell of series of different lengths.
+	if d := DTWBandDistance(0)([]float64{0, 1, 2}, []float64{0, 0, 1, 1, 2}); math.IsInf(d, 0) {
+		t.Error("DTW with a zero band is infinite for series of different lengths")
+	}
+}
+
+func TestDTWBandDistanceNegativeWindow(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("DTWBandDistance did not panic on a negative window")
+		}
+	}()
+	DTWBandDistance(-1)
+}
+
+func TestMedoidsDTWShiftedSines(t *testing.T) {
+	var data Dataset
+	for shift := range 10 {
+		data = append(data, sine(60, 20, shift))
+	}
+	for shift := range 10 {
+		data = append(data, sine(60, 40, 2*shift))
+	}
+	m := NewMedoidsTrainer(2, WithSeed(1), WithDistanceFunc(DTWDistance)).Fit(data)
+	guesses := m.Guesses()
+	for i := range data {
+		if family := i / 10; guesses[i] != guesses[family*10] {
+			t.Fatalf("series %d clustered apart from its period: %v", i, guesses)
+		}
+	}
+	if guesses[0] == guesses[10] {
+		t.Errorf("both periods in the same cluster: %v", guesses)
+	}
+}
@@ -0,0 +1,145 @@
+package kmeans
+
+import (
+	"gonum.org/v1/gonum/floats"
+	"math"
+)
+
+// WithElkan accelerate training using Elkan's algorithm, which uses the triangle inequality
+// to skip distance computations between observations and centroids that cannot be the nearest.
+// It keeps len(data)*k bounds in memory.
+// It only applies to registered metrics (see RegisterMetric), other distances fall back to the naive assignment.
+func WithElkan() TrainerOption {
+	return func(t *Trainer) {
+		t.elkan = true
+	}
+}
+
+// bounds is an assignment accelerated by distance bounds kept across iterations, see WithElkan and WithHamerly.
+type bounds interface {
+	// assign is the equivalent of Trainer.assign using the bounds to skip distance computations.
+	assign(t Trainer, model *Model, dist []float64, cb []float64, cn Dataset, buf *assignBuffers) int
+	// move adjust the bounds after the centroids were updated.
+	move(t Trainer, model *Model)
+}
+
+// elkan holds the bounds of Elkan's algorithm across iterations.
+type elkan struct {
+	k int
+	// upper is the upper bound of the distance between each observation and its centroid.
+	upper []float64
+	// lower is the lower bound of the distance between each observation and each centroid, row-major.
+	lower []float64
+	// half is half of the distance between each pair of centroids, row-major.
+	half []float64
+	// s is half of the distance between each centroid and its nearest other centroid.
+	s []float64
+	// previous is the copy of the centroids before the update step.
+	previous    Dataset
+	initialized bool
+}
+
+func newElkan(n, k int) *elkan {
+	return &elkan{
+		k:     k,
+		upper: make([]float64, n),
+		lower: make([]float64, n*k),
+		half:  make([]float64, k*k),
+		s:     make([]float64, k),
+	}
+}
+
+// assign is the equivalent of Trainer.assign using the bounds to skip distance computations.
+func (e *elkan) assign(t Trainer, model *Model, dist []float64, cb []float64, cn Dataset, buf *assignBuffers) int {
+	data := model.data
+	centroids := model.centroids
+	l := len(centroids[0])
+
+	for i := range e.k {
+		e.s[i] = math.Inf(1)
+		for j := range e.k {
+			if i == j {
+				continue
+			}
+			h := t.distanceFn(centroids[i], centroids[j]) / 2
+			e.half[i*e.k+j] = h
+			e.s[i] = min(e.s[i], h)
+		}
+	}
+
+	runChunks(len(buf.changes), len(data), func(num, from, to int) {
+		cb, cn := buf.reset(num)
+		changes := 0
+		for i := from; i < to; i++ {
+			lower := e.lower[i*e.k : (i+1)*e.k]
+			a := model.mapping[i]
+			if !e.initialized {
+				a = 0
+				for j := range e.k {
+					lower[j] = t.distanceFn(data[i], centroids[j])
+					if lower[j] < lower[a] {
+						a = j
+					}
+				}
+				e.upper[i] = lower[a]
+			} else if e.upper[i] > e.s[a] {
+				tight := false
+				for j := range e.k {
+					if j == a || e.upper[i] <= lower[j] || e.upper[i] <= e.half[a*e.k+j] {
+						continue
+					}
+					if !tight {
+						e.upper[i] = t.distanceFn(data[i], centroids[a])
+						lower[a] = e.upper[i]
+						tight = true
+						if e.upper[i] <= lower[j] || e.upper[i] <= e.half[a*e.k+j] {
+							continue
+						}
+					}
+					lower[j] = t.distanceFn(data[i], centroids[j])
+					if lower[j] < e.upper[i] {
+						a = j
+						e.upper[i] = lower[j]
+					}
+				}
+			}
+
+			if model.mapping[i] != a {
+				changes++
+			}
+			model.mapping[i] = a
+			dist[i] = e.upper[i]
+			w := model.weight(i)
+			cb[a] += w
+			floats.AddScaled(cn[a], w, data[i])
+		}
+		buf.changes[num] = changes
+	})
+	e.initialized = true
+
+	if e.previous == nil {
+		e.previous = make(Dataset, e.k)
+		for i := range e.previous {
+			e.previous[i] = make([]float64, l)
+		}
+	}
+	for i := range centroids {
+		copy(e.previous[i], centroids[i])
+	}
+	return reduce(buf.cb, buf.cn, buf.changes, cb, cn)
+}
+
+// move adjust the bounds after the centroids were updated.
+func (e *elkan) move(t Trainer, model *Model) {
+	shift := make([]float64, e.k)
+	for i := range e.k {
+		shift[i] = t.distanceFn(e.previous[i], model.centroids[i])
+	}
+	for i := range e.upper {
+		e.upper[i] += shift[model.mapping[i]]
+		lower := e.lower[i*e.k : (i+1)*e.k]
+		for j := range lower {
+			lower[j] = max(lower[j]-shift[j], 0)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package kmeans
+
+import (
+	"math/rand"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestElkanMatchesNaive(t *testing.T) {
+	data := blobs(100)
+	for _, distance := range []TrainerOption{WithDistance("EuclideanDistance"), WithDistance("ManhattanDistance"), WithDistanceFunc(EuclideanDistance)} {
+		naive := fit(t, 5, data, distance)
+		elkan := fit(t, 5, data, distance, WithElkan())
+		if !slices.Equal(elkan.Guesses(), naive.Guesses()) {
+			t.Errorf("WithElkan() assignments differ from the naive assignments")
+		}
+	}
+}
+
+func TestDistanceFuncResolvesBuiltinName(t *testing.T) {
+	tests := []struct {
+		fn   DistanceFunc
+		want string
+	}{
+		{EuclideanDistance, "EuclideanDistance"},
+		{ManhattanDistance, "ManhattanDistance"},
+		{MinkowskiDistance(2), "EuclideanDistance"},
+		{MinkowskiDistance(3), ""},
+		{func(a, b []float64) float64 { return 0 }, ""},
+	}
+	for _, tt := range tests {
+		if got := NewTrainer(2, WithDistanceFunc(tt.fn)).distanceName; got != tt.want {
+			t.Errorf("WithDistanceFunc() name = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+// benchmarkData returns a fixed dataset of n observations of the given dimension around 20 centers.
+func benchmarkData(n, dimension int) Dataset {
+	r := rand.New(rand.NewSource(1))
+	centers := make(Dataset, 20)
+	for i := range centers {
+		centers[i] = make([]float64, dimension)
+		for j := range centers[i] {
+			centers[i][j] = r.Float64() * 100
+		}
+	}
+	data := make(Dataset, n)
+	for i := range data {
+		data[i] = make([]float64, dimension)
+		for j := range data[i] {
+			data[i][j] = centers[i%len(centers)][j] + r.NormFloat64()*5
+		}
+	}
+	return data
+}
+
+// distanceCalls counts the calls of the "CountingEuclideanDistance" metric registered by benchmarkLloyd.
+var distanceCalls atomic.Int64
+
+// benchmarkLloyd trains on 50000 observations of 50 dimensions using a counting euclidean metric,
+// reporting the distance calls per training, to compare the naive assignment with the bounds of Elkan and Hamerly.
+func benchmarkLloyd(b *testing.B, options ...TrainerOption) {
+	RegisterMetric("CountingEuclideanDistance", func(a, b []float64) float64 {
+		distanceCalls.Add(1)
+		return EuclideanDistance(a, b)
+	})
+	data := benchmarkData(50000, 50)
+	t := NewTrainer(20, append([]TrainerOption{WithSeed(1), WithDistance("CountingEuclideanDistance"), WithDeltaThreshold(0)}, options...)...)
+	distanceCalls.Store(0)
+	b.ResetTimer()
+	for range b.N {
+		if _, err := t.TryFit(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(distanceCalls.Load())/float64(b.N), "distances/op")
+}
+
+func BenchmarkLloydNaive(b *testing.B) {
+	benchmarkLloyd(b)
+}
+
+func BenchmarkLloydElkan(b *testing.B) {
+	benchmarkLloyd(b, WithElkan())
+}
@@ -0,0 +1,88 @@
+package kmeans
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// modelState is the serialized form of a *Model, only what Predict requires.
+type modelState struct {
+	K         int     `json:"k"`
+	Dimension int     `json:"dimension"`
+	Distance  string  `json:"distance"`
+	Centroids Dataset `json:"centroids"`
+}
+
+func (m *Model) state() (modelState, error) {
+	if !m.fitted() {
+		return modelState{}, ErrNotFitted
+	}
+	if m.distanceName == "" {
+		return modelState{}, fmt.Errorf("%w: model trained with an unregistered distance function", ErrUnknownDistance)
+	}
+	return modelState{K: m.k, Dimension: len(m.centroids[0]), Distance: m.distanceName, Centroids: m.centroids}, nil
+}
+
+func (m *Model) restore(s modelState) error {
+	fn, ok := LookupDistance(s.Distance)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownDistance, s.Distance)
+	}
+	if s.K < 1 || len(s.Centroids) != s.K {
+		return fmt.Errorf("%w: expected %d centroids, got %d", ErrInvalidModel, s.K, len(s.Centroids))
+	}
+	if err := validateCentroids(s.Centroids, s.Dimension); err != nil {
+		return err
+	}
+	*m = Model{k: s.K, distanceFn: fn, distanceName: s.Distance, centroids: s.Centroids}
+	return nil
+}
+
+// MarshalJSON encode the centroids and the distance name of the model.
+// The model must be trained with a registered distance, see WithDistance.
+func (m *Model) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, err := m.state()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON decode a model encoded by MarshalJSON.
+// The decoded model can only be used for prediction as the training data is not serialized.
+func (m *Model) UnmarshalJSON(b []byte) error {
+	var s modelState
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return m.restore(s)
+}
+
+// MarshalBinary encode the centroids and the distance name of the model using gob,
+// which is more compact than MarshalJSON for large models.
+func (m *Model) MarshalBinary() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, err := m.state()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decode a model encoded by MarshalBinary.
+func (m *Model) UnmarshalBinary(b []byte) error {
+	var s modelState
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&s); err != nil {
+		return err
+	}
+	return m.restore(s)
+}
@@ -0,0 +1,83 @@
+package kmeans
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	data := blobs(30)
+	m := fit(t, 3, data, WithDistance("ManhattanDistance"))
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Model
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := m.PredictBatch(data)
+	got, err := decoded.PredictBatch(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("decoded model predictions differ from the original model")
+	}
+}
+
+func TestBinaryUnregisteredDistance(t *testing.T) {
+	m := fit(t, 2, blobs(10), WithDistanceFunc(func(a, b []float64) float64 { return ManhattanDistance(a, b) }))
+	if _, err := m.MarshalBinary(); !errors.Is(err, ErrUnknownDistance) {
+		t.Errorf("MarshalBinary() error = %v, want %v", err, ErrUnknownDistance)
+	}
+}
+
+func TestBinaryInvalid(t *testing.T) {
+	var m Model
+	if err := m.UnmarshalBinary([]byte("not gob")); err == nil {
+		t.Error("UnmarshalBinary() succeeded on invalid input")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	data := blobs(30)
+	m := fit(t, 3, data, WithDistance("ManhattanDistance"))
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Model
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := m.PredictBatch(data)
+	got, err := decoded.PredictBatch(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("decoded model predictions differ from the original model")
+	}
+	if !slices.EqualFunc(decoded.Centroids(), m.Centroids(), slices.Equal) {
+		t.Errorf("decoded centroids %v, want %v", decoded.Centroids(), m.Centroids())
+	}
+}
+
+func TestJSONUnregisteredDistance(t *testing.T) {
+	b, err := json.Marshal(fit(t, 2, blobs(10), WithDistance("ManhattanDistance")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b = bytes.Replace(b, []byte(`"ManhattanDistance"`), []byte(`"NoSuchDistance"`), 1)
+	var m Model
+	if err := json.Unmarshal(b, &m); !errors.Is(err, ErrUnknownDistance) {
+		t.Errorf("UnmarshalJSON() error = %v, want %v", err, ErrUnknownDistance)
+	}
+	if m.IsFitted() {
+		t.Error("UnmarshalJSON() left a fitted model after failing")
+	}
+}
@@ -0,0 +1,42 @@
+package kmeans
+
+import "errors"
+
+var (
+	// ErrEmptyDataset is returned when training on a dataset without any observation.
+	ErrEmptyDataset = errors.New("kmeans: empty dataset")
+	// ErrInvalidClusters is returned when the number of clusters is not in [1, len(data)].
+	ErrInvalidClusters = errors.New("kmeans: invalid number of clusters")
+	// ErrZeroIterations is returned when the maximum number of iterations is not positive.
+	ErrZeroIterations = errors.New("kmeans: maximum iterations must be positive")
+	// ErrUnknownDistance is returned when a distance function is not registered.
+	ErrUnknownDistance = errors.New("kmeans: unknown distance")
+	// ErrNotFitted is returned when using a model that has not been trained.
+	ErrNotFitted = errors.New("kmeans: model is not fitted")
+	// ErrDimensionMismatch is returned when an observation does not have the expected number of dimensions.
+	ErrDimensionMismatch = errors.New("kmeans: dimension mismatch")
+	// ErrInvalidWeights is returned when the observation weights are not one non-negative finite value per observation.
+	ErrInvalidWeights = errors.New("kmeans: invalid weights")
+	// ErrInvalidFuzzifier is returned when the fuzzy c-means fuzzifier is not greater than 1.
+	ErrInvalidFuzzifier = errors.New("kmeans: fuzzifier must be greater than 1")
+	// ErrNonFinite is returned when an observation contains a NaN or infinite value.
+	ErrNonFinite = errors.New("kmeans: non-finite value")
+	// ErrInvalidQuantile is returned when a quantile is not in [0, 1].
+	ErrInvalidQuantile = errors.New("kmeans: quantile must be in [0, 1]")
+	// ErrLabelsMismatch is returned when comparing labelings of different lengths.
+	ErrLabelsMismatch = errors.New("kmeans: labelings have different lengths")
+	// ErrInvalidConstraints is returned when a constraint refers to an observation outside the dataset.
+	ErrInvalidConstraints = errors.New("kmeans: invalid constraints")
+	// ErrUnsatisfiable is returned when the constraints cannot be satisfied.
+	ErrUnsatisfiable = errors.New("kmeans: unsatisfiable constraints")
+	// ErrInvalidTemperature is returned when a softmax temperature is not positive and finite.
+	ErrInvalidTemperature = errors.New("kmeans: temperature must be positive")
+	// ErrDistanceMismatch is returned when combining models trained with different distances.
+	ErrDistanceMismatch = errors.New("kmeans: distance mismatch")
+	// ErrInvalidModel is returned when decoding a malformed model.
+	ErrInvalidModel = errors.New("kmeans: invalid model")
+	// ErrIncompatibleOptions is returned when the trainer combines options that cannot be applied together.
+	ErrIncompatibleOptions = errors.New("kmeans: incompatible options")
+	// ErrNotResumable is returned when continuing the training of a model that was not fitted by a Trainer.
+	ErrNotResumable = errors.New("kmeans: model cannot be resumed")
+)
@@ -0,0 +1,494 @@
+package kmeans
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// Float is the constraint of the coordinate types of the generic distances.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Dataset32 is a list of float32 observations, halving the memory of a Dataset, see Trainer.FitFloat32.
+type Dataset32 [][]float32
+
+// DistanceFunc32 represents a function for measuring distance between n-dimensional float32 vectors.
+type DistanceFunc32 func([]float32, []float32) float64
+
+// EuclideanDistanceOf is EuclideanDistance for any Float type, accumulated in float64.
+func EuclideanDistanceOf[T Float](a, b []T) float64 {
+	return math.Sqrt(EuclideanDistanceSquaredOf(a, b))
+}
+
+// EuclideanDistanceSquaredOf is EuclideanDistanceSquared for any Float type, accumulated in float64.
+func EuclideanDistanceSquaredOf[T Float](a, b []T) float64 {
+	var s float64
+
+	for i := range a {
+		t := float64(a[i]) - float64(b[i])
+		s += t * t
+	}
+
+	return s
+}
+
+// ManhattanDistanceOf is ManhattanDistance for any Float type, accumulated in float64.
+func ManhattanDistanceOf[T Float](a, b []T) float64 {
+	var s float64
+
+	for i := range a {
+		s += math.Abs(float64(a[i]) - float64(b[i]))
+	}
+
+	return s
+}
+
+// ChebyshevDistanceOf is ChebyshevDistance for any Float type.
+func ChebyshevDistanceOf[T Float](a, b []T) float64 {
+	var s float64
+
+	for i := range a {
+		s = max(s, math.Abs(float64(a[i])-float64(b[i])))
+	}
+
+	return s
+}
+
+// CosineDistanceOf is CosineDistance for any Float type, accumulated in float64.
+func CosineDistanceOf[T Float](a, b []T) float64 {
+	var (
+		dot, na, nb float64
+	)
+
+	for i := range a {
+		x, y := float64(a[i]), float64(b[i])
+		dot += x * y
+		na += x * x
+		nb += y * y
+	}
+
+	if na == 0 || nb == 0 {
+		return 1
+	}
+	return 1 - dot/math.Sqrt(na*nb)
+}
+
+// distances32 are the float32 forms of the registered distances, by name.
+var distances32 = map[string]DistanceFunc32{
+	"EuclideanDistance":        EuclideanDistanceOf[float32],
+	"EuclideanDistanceSquared": EuclideanDistanceSquaredOf[float32],
+	"ManhattanDistance":        ManhattanDistanceOf[float32],
+	"ChebyshevDistance":        ChebyshevDistanceOf[float32],
+	"CosineDistance":           CosineDistanceOf[float32],
+}
+
+// Model32 is a kmeans model trained on float32 observations, see Trainer.FitFloat32.
+type Model32 struct {
+	mu         sync.RWMutex
+	distanceFn DistanceFunc32
+	k          int
+	data       Dataset32
+	centroids  Dataset32
+	mapping    []int
+	sizes      []int
+	iter       int
+	inertia    float64
+	shift      float64
+	reason     ConvergenceReason
+}
+
+// FitFloat32 create and train a *Model32 on float32 observations, using the same kmeans++ initialization
+// and Lloyd iterations as Fit while keeping the observations and centroids in float32.
+// Distances and centroid sums are accumulated in float64.
+// The distance must be one of EuclideanDistance, EuclideanDistanceSquared, ManhattanDistance, ChebyshevDistance
+// or CosineDistance set by name, see WithDistance.
+// Only the number of clusters, iterations, delta threshold, tolerance, seed, concurrency and empty cluster strategy apply,
+// other options fail with ErrIncompatibleOptions.
+func (t Trainer) FitFloat32(data Dataset32) (*Model32, error) {
+	return t.FitFloat32Context(context.Background(), data)
+}
+
+// FitFloat32Context is FitFloat32 stopping early when ctx is done.
+// On cancellation, the partially trained *Model32 is returned along with ctx.Err().
+func (t Trainer) FitFloat32Context(ctx context.Context, data Dataset32) (*Model32, error) {
+	distanceFn, ok := distances32[t.distanceName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q has no float32 form", ErrUnknownDistance, t.distanceName)
+	}
+	if err := t.validate32(data); err != nil {
+		return nil, err
+	}
+
+	rng := t.newRand()
+	model := &Model32{distanceFn: distanceFn, k: t.k, data: data, mapping: make([]int, len(data))}
+	model.initialize(rng)
+	iter, err := t.lloyd32(ctx, model, rng)
+	model.iter = iter
+	model.summarize()
+	return model, err
+}
+
+func (t Trainer) validate32(data Dataset32) error {
+	if option := t.unsupported32(); option != "" {
+		return fmt.Errorf("%w: float32 training does not support %s", ErrIncompatibleOptions, option)
+	}
+	if t.maxIterations < 1 {
+		return fmt.Errorf("%w: got %d", ErrZeroIterations, t.maxIterations)
+	}
+	if t.k < 1 {
+		return fmt.Errorf("%w: got %d clusters", ErrInvalidClusters, t.k)
+	}
+	if len(data) == 0 {
+		return ErrEmptyDataset
+	}
+	if t.k > len(data) {
+		return fmt.Errorf("%w: %d clusters requested for %d observations", ErrInvalidClusters, t.k, len(data))
+	}
+	if len(data[0]) == 0 {
+		return fmt.Errorf("%w: observations must have at least one dimension", ErrDimensionMismatch)
+	}
+	for i := range data {
+		if len(data[i]) != len(data[0]) {
+			return fmt.Errorf("%w: observation %d has %d dimensions, expected %d", ErrDimensionMismatch, i, len(data[i]), len(data[0]))
+		}
+		if j := nonFiniteOf(data[i]); j >= 0 {
+			return fmt.Errorf("%w: observation %d has %v at dimension %d", ErrNonFinite, i, data[i][j], j)
+		}
+	}
+	return nil
+}
+
+// unsupported32 returns the name of the first configured option that float32 training does not apply, or "".
+func (t Trainer) unsupported32() string {
+	switch {
+	case t.spherical:
+		return "WithSpherical"
+	case t.batchSize > 0:
+		return "WithMiniBatch"
+	case t.elkan:
+		return "WithElkan"
+	case t.hamerly:
+		return "WithHamerly"
+	case t.update != Lloyd:
+		return "WithUpdateMode"
+	case t.history:
+		return "WithInertiaHistory"
+	case t.callback != nil:
+		return "WithIterationCallback"
+	case t.dba > 0:
+		return "WithDBAUpdate"
+	case t.median:
+		return "WithMedianUpdate"
+	case t.greedy > 1:
+		return "WithGreedyInit"
+	case t.restarts > 1:
+		return "WithRestarts"
+	case t.initial != nil:
+		return "WithInitialCentroids"
+	case t.init != KMeansPlusPlus:
+		return "WithInit"
+	case t.canonical:
+		return "WithCanonicalLabels"
+	case t.minSize > 0:
+		return "WithMinClusterSize"
+	case t.mustLink != nil || t.cannotLink != nil:
+		return "WithConstraints"
+	case t.schedule != nil:
+		return "WithOnlineSchedule"
+	}
+	return ""
+}
+
+// nonFiniteOf is nonFinite for any Float type.
+func nonFiniteOf[T Float](p []T) int {
+	for j, v := range p {
+		if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+			return j
+		}
+	}
+	return -1
+}
+
+// initialize pick the initial centroids using kmeans++, never picking the same observation twice.
+func (m *Model32) initialize(rng *rand.Rand) {
+	picked := make([]bool, len(m.data))
+	m.centroids = make(Dataset32, 0, m.k)
+	pick := func(i int) {
+		picked[i] = true
+		m.centroids = append(m.centroids, slices.Clone(m.data[i]))
+	}
+	pick(rng.Intn(len(m.data)))
+
+	// d is the squared distance of each observation to its nearest centroid, 0 for picked ones.
+	d := make([]float64, len(m.data))
+	for j := range d {
+		d[j] = math.Inf(1)
+	}
+	for len(m.centroids) < m.k {
+		last := m.centroids[len(m.centroids)-1]
+		s := float64(0)
+		for j := range d {
+			if picked[j] {
+				d[j] = 0
+				continue
+			}
+			l := m.distanceFn(last, m.data[j])
+			d[j] = min(d[j], l*l)
+			s += d[j]
+		}
+
+		if s == 0 {
+			// Every observation lies on a centroid, fallback to an uniform pick among the remaining ones.
+			k := rng.Intn(len(m.data) - len(m.centroids))
+			for j := range picked {
+				if picked[j] {
+					continue
+				}
+				if k == 0 {
+					pick(j)
+					break
+				}
+				k--
+			}
+			continue
+		}
+
+		target := rng.Float64() * s
+		k := -1
+		for j := range d {
+			if d[j] == 0 {
+				continue
+			}
+			k = j
+			if target -= d[j]; target < 0 {
+				break
+			}
+		}
+		pick(k)
+	}
+}
+
+// lloyd32 run the batch kmeans iterations on an initialized model, returns the number of iterations run.
+func (t Trainer) lloyd32(ctx context.Context, model *Model32, rng *rand.Rand) (int, error) {
+	n, l := len(model.data), len(model.data[0])
+	changeThreshold := int(float64(n) * t.delta)
+	dist := make([]float64, n)
+	workers := t.workers(n)
+	icb, icn, ichanges := make([][]float64, workers), make([]Dataset, workers), make([]int, workers)
+	for num := range workers {
+		icb[num], icn[num] = prepare(t.k, l)
+	}
+	cb, cn := prepare(t.k, l)
+	previous := make(Dataset32, t.k)
+	for i := range previous {
+		previous[i] = make([]float32, l)
+	}
+
+	model.reason = ReasonMaxIterations
+	iter := 0
+	for ; iter < t.maxIterations; iter++ {
+		if err := ctx.Err(); err != nil {
+			model.reason = ReasonCancelled
+			return iter, err
+		}
+		for i := range previous {
+			copy(previous[i], model.centroids[i])
+		}
+
+		runChunks(workers, n, func(num, from, to int) {
+			cb, cn := icb[num], icn[num]
+			clear(cb)
+			for i := range cn {
+				clear(cn[i])
+			}
+			changes := 0
+			for i := from; i < to; i++ {
+				c, d := model.nearest(model.data[i])
+				if model.mapping[i] != c {
+					changes++
+				}
+				model.mapping[i] = c
+				dist[i] = d
+				cb[c]++
+				for j, v := range model.data[i] {
+					cn[c][j] += float64(v)
+				}
+			}
+			ichanges[num] = changes
+		})
+		changes := reduce(icb, icn, ichanges, cb, cn)
+
+		reinitialized := false
+		for i := range t.k {
+			if cb[i] == 0 {
+				reinitialized = t.reinitialize32(model, i, dist, rng) || reinitialized
+				continue
+			}
+			for j := range cn[i] {
+				model.centroids[i][j] = float32(cn[i][j] / cb[i])
+				cn[i][j] = 0
+			}
+			cb[i] = 0
+		}
+
+		model.shift = 0
+		for i := range previous {
+			model.shift = max(model.shift, model.distanceFn(previous[i], model.centroids[i]))
+		}
+		if reinitialized {
+			continue
+		}
+		if changes == 0 || changes < changeThreshold {
+			model.reason = ReasonChangesStable
+			return iter + 1, nil
+		}
+		if model.shift < t.tolerance {
+			model.reason = ReasonToleranceMet
+			return iter + 1, nil
+		}
+	}
+	return iter, nil
+}
+
+// reinitialize32 is reinitialize for a *Model32.
+func (t Trainer) reinitialize32(m *Model32, i int, dist []float64, rng *rand.Rand) bool {
+	p := 0
+	switch t.empty {
+	case EmptyClusterKeep:
+		return false
+	case EmptyClusterRandom:
+		p = rng.Intn(len(m.data))
+	default:
+		for j := range dist {
+			if dist[j] > dist[p] {
+				p = j
+			}
+		}
+	}
+	moved := dist[p] > 0
+	dist[p] = 0
+	m.centroids[i] = slices.Clone(m.data[p])
+	return moved
+}
+
+// nearest returns the nearest centroid of p and the distance to it.
+func (m *Model32) nearest(p []float32) (int, float64) {
+	c := 0
+	n := m.distanceFn(p, m.centroids[0])
+	for i := 1; i < len(m.centroids); i++ {
+		if d := m.distanceFn(p, m.centroids[i]); d < n {
+			n = d
+			c = i
+		}
+	}
+	return c, n
+}
+
+// summarize caches the statistics of the fitted model.
+func (m *Model32) summarize() {
+	m.sizes = make([]int, m.k)
+	m.inertia = 0
+	for i, c := range m.mapping {
+		m.sizes[c]++
+		m.inertia += EuclideanDistanceSquaredOf(m.data[i], m.centroids[c])
+	}
+}
+
+// Predict returns number of cluster to which the observation would be assigned, in [0, k).
+// It panics like Model.Predict.
+func (m *Model32) Predict(p []float32) int {
+	c, _ := m.PredictWithDistance(p)
+	return c
+}
+
+// PredictWithDistance returns number of cluster to which the observation would be assigned
+// and the distance between the observation and that cluster centroid.
+func (m *Model32) PredictWithDistance(p []float32) (int, float64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(Dataset32{p}); err != nil {
+		panic(err)
+	}
+	return m.nearest(p)
+}
+
+// PredictBatch returns number of cluster to which each observation would be assigned.
+func (m *Model32) PredictBatch(points Dataset32) ([]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(points); err != nil {
+		return nil, err
+	}
+	labels := make([]int, len(points))
+	for i := range points {
+		labels[i], _ = m.nearest(points[i])
+	}
+	return labels, nil
+}
+
+func (m *Model32) validatePoints(points Dataset32) error {
+	if len(m.centroids) == 0 {
+		return ErrNotFitted
+	}
+	l := len(m.centroids[0])
+	for i := range points {
+		if len(points[i]) != l {
+			return fmt.Errorf("%w: observation %d has %d dimensions, expected %d", ErrDimensionMismatch, i, len(points[i]), l)
+		}
+		if j := nonFiniteOf(points[i]); j >= 0 {
+			return fmt.Errorf("%w: observation %d has %v at dimension %d", ErrNonFinite, i, points[i][j], j)
+		}
+	}
+	return nil
+}
+
+// Centroids returns a copy of all clusters centroids, row i is the centroid of cluster i as numbered by Predict.
+func (m *Model32) Centroids() Dataset32 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c := make(Dataset32, len(m.centroids))
+	for i := range m.centroids {
+		c[i] = slices.Clone(m.centroids[i])
+	}
+	return c
+}
+
+// Guesses returns a copy of the mapping from data point indices to cluster numbers, in [0, k) like Predict.
+func (m *Model32) Guesses() []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.mapping)
+}
+
+// Sizes returns a copy of the number of observations assigned to each cluster.
+func (m *Model32) Sizes() []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.sizes)
+}
+
+// Inertia returns the within-cluster sum of squares, like Model.Inertia.
+func (m *Model32) Inertia() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inertia
+}
+
+// Iter returns model number of iterations run.
+func (m *Model32) Iter() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.iter
+}
+
+// ConvergedReason returns why the training stopped.
+func (m *Model32) ConvergedReason() ConvergenceReason {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.reason
+}
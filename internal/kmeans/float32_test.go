@@ -0,0 +1,94 @@
+package kmeans
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func toFloat32(data Dataset) Dataset32 {
+	out := make(Dataset32, len(data))
+	for i := range data {
+		out[i] = make([]float32, len(data[i]))
+		for j, v := range data[i] {
+			out[i][j] = float32(v)
+		}
+	}
+	return out
+}
+
+func TestFitFloat32MatchesFloat64(t *testing.T) {
+	data := blobs(50)
+	for _, distance := range []string{"EuclideanDistance", "ManhattanDistance", "ChebyshevDistance"} {
+		m32, err := NewTrainer(3, WithSeed(1), WithDistance(distance)).FitFloat32(toFloat32(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m64 := fit(t, 3, data, WithDistance(distance))
+		ari, err := AdjustedRandIndex(m32.Guesses(), m64.Guesses())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ari != 1 {
+			t.Errorf("%s: float32 and float64 clusterings differ, ARI = %v", distance, ari)
+		}
+		if r := m32.Inertia() / m64.Inertia(); math.Abs(r-1) > 1e-5 {
+			t.Errorf("%s: Inertia() = %v, want %v", distance, m32.Inertia(), m64.Inertia())
+		}
+		if c := m32.Predict([]float32{20, 10}); c != m32.Guesses()[len(data)-1] {
+			t.Errorf("%s: Predict() = %d, want the cluster of the last blob", distance, c)
+		}
+	}
+}
+
+func TestFitFloat32Errors(t *testing.T) {
+	if _, err := NewTrainer(1, WithDistance("HaversineDistance")).FitFloat32(Dataset32{{0, 0}}); !errors.Is(err, ErrUnknownDistance) {
+		t.Errorf("FitFloat32() error = %v, want %v", err, ErrUnknownDistance)
+	}
+	if _, err := NewTrainer(1).FitFloat32(Dataset32{{0, 0}, {1}}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("FitFloat32() error = %v, want %v", err, ErrDimensionMismatch)
+	}
+	if _, err := NewTrainer(1).FitFloat32(Dataset32{{float32(math.NaN())}}); !errors.Is(err, ErrNonFinite) {
+		t.Errorf("FitFloat32() error = %v, want %v", err, ErrNonFinite)
+	}
+}
+
+func TestFitFloat32UnsupportedOptions(t *testing.T) {
+	data := toFloat32(blobs(5))
+	options := map[string]TrainerOption{
+		"WithSpherical":        WithSpherical(),
+		"WithElkan":            WithElkan(),
+		"WithRestarts":         WithRestarts(3),
+		"WithInitialCentroids": WithInitialCentroids(Dataset{{0, 0}, {10, 5}}),
+		"WithCanonicalLabels":  WithCanonicalLabels(),
+		"WithMiniBatch":        WithMiniBatch(4),
+		"WithInit":             WithInit(Forgy),
+	}
+	for name, option := range options {
+		if _, err := NewTrainer(2, option).FitFloat32(data); !errors.Is(err, ErrIncompatibleOptions) {
+			t.Errorf("%s: FitFloat32() error = %v, want %v", name, err, ErrIncompatibleOptions)
+		}
+	}
+	if _, err := NewTrainer(2, WithSeed(1), WithTolerance(1e-3), WithEmptyClusterStrategy(EmptyClusterKeep)).FitFloat32(data); err != nil {
+		t.Errorf("FitFloat32() error = %v with supported options", err)
+	}
+}
+
+func TestDistanceOf(t *testing.T) {
+	a, b := []float64{1, 2, 3}, []float64{4, 0, 3}
+	a32, b32 := []float32{1, 2, 3}, []float32{4, 0, 3}
+	tests := []struct {
+		name      string
+		want, got float64
+	}{
+		{"euclidean", EuclideanDistance(a, b), EuclideanDistanceOf(a32, b32)},
+		{"manhattan", ManhattanDistance(a, b), ManhattanDistanceOf(a32, b32)},
+		{"chebyshev", ChebyshevDistance(a, b), ChebyshevDistanceOf(a32, b32)},
+		{"cosine", CosineDistance(a, b), CosineDistanceOf(a32, b32)},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-12 {
+			t.Errorf("%s: float32 distance = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package kmeans
+
+import (
+	"context"
+	"fmt"
+	"gonum.org/v1/gonum/floats"
+	"math"
+	"slices"
+)
+
+// FuzzyTrainer train fuzzy c-means models, where each observation has a membership degree to every cluster.
+type FuzzyTrainer struct {
+	Trainer
+	fuzziness float64
+}
+
+// FuzzyModel is a fuzzy c-means model.
+// Its centroids, Guesses and metrics use the hard assignment of each observation to its highest membership.
+type FuzzyModel struct {
+	view
+	fuzziness   float64
+	memberships Dataset
+}
+
+// NewFuzzyTrainer create new FuzzyTrainer, m is the fuzzifier (m > 1), larger values give softer memberships.
+// The delta threshold is the maximum change of membership between two iterations to consider the model converged.
+func NewFuzzyTrainer(k int, m float64, options ...TrainerOption) FuzzyTrainer {
+	return FuzzyTrainer{Trainer: NewTrainer(k, options...), fuzziness: m}
+}
+
+// Fit create and train the *FuzzyModel.
+// It panics if the dataset or the trainer configuration is invalid, use TryFit to get an error instead.
+func (t FuzzyTrainer) Fit(data Dataset) *FuzzyModel {
+	m, err := t.TryFit(data)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryFit create and train the *FuzzyModel, returning an error if the dataset or the trainer configuration is invalid.
+func (t FuzzyTrainer) TryFit(data Dataset) (*FuzzyModel, error) {
+	return t.FitContext(context.Background(), data)
+}
+
+// FitContext create and train the *FuzzyModel, stopping early when ctx is done.
+func (t FuzzyTrainer) FitContext(ctx context.Context, data Dataset) (*FuzzyModel, error) {
+	if err := t.validate(data); err != nil {
+		return nil, err
+	}
+	if !(t.fuzziness > 1) {
+		return nil, fmt.Errorf("%w: got %v", ErrInvalidFuzzifier, t.fuzziness)
+	}
+
+	model := &Model{data: data, k: t.k, distanceFn: t.distanceFn, distanceName: t.distanceName}
+	t.initialize(ctx, model, t.newRand())
+	fm := &FuzzyModel{view: view{model: model}, fuzziness: t.fuzziness, memberships: make(Dataset, len(data))}
+	for i := range fm.memberships {
+		fm.memberships[i] = make([]float64, t.k)
+	}
+
+	var err error
+	iter := 0
+	for ; iter < t.maxIterations; iter++ {
+		if err = ctx.Err(); err != nil {
+			model.reason = ReasonCancelled
+			break
+		}
+		change := t.updateMemberships(fm)
+		t.updateCentroids(fm)
+		if change < t.delta {
+			model.reason = ReasonChangesStable
+			iter++
+			break
+		}
+	}
+
+	for i, u := range fm.memberships {
+		model.mapping[i] = floats.MaxIdx(u)
+	}
+	model.iter = iter
+	model.summarize()
+	if t.canonical {
+		perm := model.canonicalize()
+		for i := range fm.memberships {
+			fm.memberships[i] = permute(fm.memberships[i], perm)
+		}
+	}
+	return fm, err
+}
+
+// updateMemberships compute the memberships from the current centroids, returns the maximum membership change.
+func (t FuzzyTrainer) updateMemberships(fm *FuzzyModel) float64 {
+	workers := t.workers(len(fm.model.data))
+	changes := make([]float64, workers)
+	runChunks(workers, len(fm.model.data), func(num, from, to int) {
+		u := make([]float64, t.k)
+		for i := from; i < to; i++ {
+			fm.membership(fm.model.data[i], u)
+			for j := range u {
+				changes[num] = max(changes[num], math.Abs(u[j]-fm.memberships[i][j]))
+			}
+			copy(fm.memberships[i], u)
+		}
+	})
+	return floats.Max(changes)
+}
+
+// updateCentroids compute each centroid as the mean of all observations weighted by their membership^m.
+func (t FuzzyTrainer) updateCentroids(fm *FuzzyModel) {
+	l := len(fm.model.centroids[0])
+	for j := range t.k {
+		c := make([]float64, l)
+		s := float64(0)
+		for i := range fm.model.data {
+			w := math.Pow(fm.memberships[i][j], fm.fuzziness)
+			floats.AddScaled(c, w, fm.model.data[i])
+			s += w
+		}
+		if s > 0 {
+			floats.Scale(1/s, c)
+			fm.model.centroids[j] = c
+		}
+	}
+}
+
+// membership compute the membership of p to each cluster into u.
+func (fm *FuzzyModel) membership(p []float64, u []float64) {
+	exp := 2 / (fm.fuzziness - 1)
+	zeros := 0
+	for j := range u {
+		u[j] = fm.model.distanceFn(p, fm.model.centroids[j])
+		if u[j] == 0 {
+			zeros++
+		}
+	}
+
+	// The observation lies on some centroids, share its membership between them.
+	if zeros > 0 {
+		for j := range u {
+			if u[j] == 0 {
+				u[j] = 1 / float64(zeros)
+			} else {
+				u[j] = 0
+			}
+		}
+		return
+	}
+
+	for j := range u {
+		u[j] = math.Pow(u[j], -exp)
+	}
+	floats.Scale(1/floats.Sum(u), u)
+}
+
+// Memberships returns a copy of the membership of each observation to each cluster, each row sums to 1.
+func (fm *FuzzyModel) Memberships() Dataset {
+	fm.model.mu.RLock()
+	defer fm.model.mu.RUnlock()
+	u := make(Dataset, len(fm.memberships))
+	for i := range fm.memberships {
+		u[i] = slices.Clone(fm.memberships[i])
+	}
+	return u
+}
+
+// Predict returns the membership of the observation to each cluster.
+func (fm *FuzzyModel) Predict(p []float64) []float64 {
+	fm.model.mu.RLock()
+	defer fm.model.mu.RUnlock()
+	u := make([]float64, fm.model.k)
+	fm.membership(p, u)
+	return u
+}
@@ -0,0 +1,50 @@
+package kmeans
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestFuzzyMembershipsSumToOne(t *testing.T) {
+	m := NewFuzzyTrainer(3, 2, WithSeed(1)).Fit(blobs(30))
+	for i, u := range m.Memberships() {
+		if s := floats.Sum(u); math.Abs(s-1) > 1e-9 {
+			t.Fatalf("memberships of observation %d sum to %v", i, s)
+		}
+		if floats.MaxIdx(u) != m.Guesses()[i] {
+			t.Fatalf("observation %d guessed in %d, expected its highest membership %d", i, m.Guesses()[i], floats.MaxIdx(u))
+		}
+	}
+	if s := floats.Sum(m.Predict([]float64{5, 2.5})); math.Abs(s-1) > 1e-9 {
+		t.Errorf("predicted memberships sum to %v", s)
+	}
+}
+
+func TestFuzzinessFlattensMemberships(t *testing.T) {
+	data := blobs(30)
+	// meanMax is the mean of the highest membership of each observation, 1 for hard assignments and 1/k for uniform ones.
+	meanMax := func(m float64) float64 {
+		s := float64(0)
+		for _, u := range NewFuzzyTrainer(3, m, WithSeed(1)).Fit(data).Memberships() {
+			s += floats.Max(u)
+		}
+		return s / float64(len(data))
+	}
+	previous := math.Inf(1)
+	for _, m := range []float64{1.5, 2, 4, 8} {
+		v := meanMax(m)
+		if !(v < previous) {
+			t.Errorf("fuzziness %v: mean highest membership %v, expected less than %v", m, v, previous)
+		}
+		previous = v
+	}
+}
+
+func TestFuzzyInvalidFuzzifier(t *testing.T) {
+	if _, err := NewFuzzyTrainer(3, 1, WithSeed(1)).TryFit(blobs(5)); !errors.Is(err, ErrInvalidFuzzifier) {
+		t.Errorf("fuzziness 1: error %v, expected ErrInvalidFuzzifier", err)
+	}
+}
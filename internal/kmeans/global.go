@@ -0,0 +1,112 @@
+package kmeans
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// globalCandidates is the number of observations tried as the new centroid at each step of the global initialization.
+const globalCandidates = 32
+
+// WithGlobalInit initialize centroids using global kmeans (Likas et al., 2003): starting from the mean,
+// a cluster is added at a time by running kmeans from the previous centroids plus a candidate observation,
+// keeping the candidate giving the lowest inertia. Candidates are up to 32 observations sampled like kmeans++,
+// instead of every observation, so it costs up to 32·k full trainings.
+// This is expensive, but gives very stable results.
+// This is a shorthand for WithInit(GlobalKMeans).
+func WithGlobalInit() TrainerOption {
+	return WithInit(GlobalKMeans)
+}
+
+// seedGlobal returns the initial centroids picked by global kmeans.
+func (t Trainer) seedGlobal(ctx context.Context, m *Model, rng *rand.Rand) Dataset {
+	sub := t
+	sub.init = KMeansPlusPlus
+	sub.restarts = 0
+	sub.callback = nil
+	sub.history = false
+
+	mean := make([]float64, len(m.data[0]))
+	total := float64(0)
+	for i := range m.data {
+		floats.AddScaled(mean, m.weight(i), m.data[i])
+		total += m.weight(i)
+	}
+	floats.Scale(1/total, mean)
+	if t.spherical {
+		normalize(mean)
+	}
+
+	centroids := Dataset{mean}
+	for len(centroids) < t.k && ctx.Err() == nil {
+		sub.k = len(centroids) + 1
+		var best *Model
+		for _, c := range m.candidates(centroids, rng, globalCandidates) {
+			sub.initial = append(slices.Clone(centroids), m.data[c])
+			model, err := sub.run(ctx, m.data, m.weights, rng)
+			if err != nil {
+				break
+			}
+			if best == nil || model.inertia < best.inertia {
+				best = model
+			}
+		}
+		if best == nil {
+			break
+		}
+		centroids = best.centroids
+	}
+
+	// Reached when cancelled, or when every observation already lies on a centroid so there is no candidate left.
+	for len(centroids) < t.k {
+		centroids = append(centroids, slices.Clone(m.data[rng.Intn(len(m.data))]))
+	}
+	return centroids
+}
+
+// candidates returns up to n distinct observation indices sampled with probability proportional
+// to their weighted squared distance to the nearest of centroids.
+func (m *Model) candidates(centroids Dataset, rng *rand.Rand, n int) []int {
+	distanceFn, squared := compareFn(m.distanceFn)
+	d := make([]float64, len(m.data))
+	s := float64(0)
+	remaining := 0
+	for i := range m.data {
+		l := distanceFn(m.data[i], centroids[0])
+		for _, c := range centroids[1:] {
+			l = min(l, distanceFn(m.data[i], c))
+		}
+		if !squared {
+			l *= l
+		}
+		d[i] = l * m.weight(i)
+		s += d[i]
+		if d[i] > 0 {
+			remaining++
+		}
+	}
+
+	// Stop on the count of positive weights rather than on s, which keeps some rounding residue.
+	var picked []int
+	for len(picked) < n && remaining > 0 {
+		t := rng.Float64() * s
+		k := -1
+		acc := float64(0)
+		for i, v := range d {
+			if v > 0 {
+				k = i
+				if acc += v; acc >= t {
+					break
+				}
+			}
+		}
+		picked = append(picked, k)
+		s -= d[k]
+		d[k] = 0
+		remaining--
+	}
+	return picked
+}
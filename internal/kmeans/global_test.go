@@ -0,0 +1,40 @@
+package kmeans
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestGlobalInitSmallDatasets(t *testing.T) {
+	datasets := map[string]Dataset{
+		"n close to k": {{0, 0}, {1, 0}, {0, 1}, {1, 1}, {5, 5}},
+		"duplicates":   {{0.1, 0.2}, {0.1, 0.2}, {0.1, 0.2}, {0.3, 0.7}, {0.3, 0.7}, {1e-9, 3}, {1e-9, 3}, {0.1, 0.2}, {7, 0.3}, {7, 0.3}, {0.3, 0.7}, {1e-9, 3}},
+	}
+	for name, data := range datasets {
+		for k := 1; k <= len(data); k++ {
+			for seed := range int64(25) {
+				if _, err := NewTrainer(k, WithSeed(seed), WithGlobalInit()).TryFit(data); err != nil {
+					t.Fatalf("%s, k=%d, seed %d: %v", name, k, seed, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCandidatesDistinct(t *testing.T) {
+	data := Dataset{{0.1}, {0.1}, {0.2}, {0.3}, {0.3}, {0.7}}
+	m := &Model{data: data, distanceFn: EuclideanDistance}
+	rng := rand.New(rand.NewSource(1))
+	for range 200 {
+		// Only 0.3 and 0.7 are away from the centroids.
+		got := m.candidates(Dataset{{0.1}, {0.2}}, rng, 32)
+		slices.Sort(got)
+		if !slices.Equal(got, []int{3, 4, 5}) {
+			t.Fatalf("candidates %v, expected [3 4 5]", got)
+		}
+	}
+	if got := m.candidates(Dataset{{0.1}, {0.2}, {0.3}, {0.7}}, rng, 32); len(got) != 0 {
+		t.Errorf("candidates %v when every observation lies on a centroid, expected none", got)
+	}
+}
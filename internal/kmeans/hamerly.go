@@ -0,0 +1,127 @@
+package kmeans
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// WithHamerly accelerate training using Hamerly's algorithm, which like WithElkan uses the triangle inequality
+// to skip distance computations, but only keeps one lower bound per observation, so 2*len(data) bounds in memory.
+// It is usually faster than Elkan in low to moderate dimensions.
+// It only applies to registered metrics (see RegisterMetric), other distances fall back to the naive assignment.
+func WithHamerly() TrainerOption {
+	return func(t *Trainer) {
+		t.hamerly = true
+	}
+}
+
+// hamerly holds the bounds of Hamerly's algorithm across iterations.
+type hamerly struct {
+	k int
+	// upper is the upper bound of the distance between each observation and its centroid.
+	upper []float64
+	// lower is the lower bound of the distance between each observation and its second nearest centroid.
+	lower []float64
+	// s is half of the distance between each centroid and its nearest other centroid.
+	s []float64
+	// previous is the copy of the centroids before the update step.
+	previous    Dataset
+	initialized bool
+}
+
+func newHamerly(n, k int) *hamerly {
+	return &hamerly{
+		k:     k,
+		upper: make([]float64, n),
+		lower: make([]float64, n),
+		s:     make([]float64, k),
+	}
+}
+
+// assign is the equivalent of Trainer.assign using the bounds to skip distance computations.
+func (h *hamerly) assign(t Trainer, model *Model, dist []float64, cb []float64, cn Dataset, buf *assignBuffers) int {
+	data := model.data
+	centroids := model.centroids
+
+	for i := range h.k {
+		h.s[i] = math.Inf(1)
+		for j := range h.k {
+			if i != j {
+				h.s[i] = min(h.s[i], t.distanceFn(centroids[i], centroids[j])/2)
+			}
+		}
+	}
+
+	runChunks(len(buf.changes), len(data), func(num, from, to int) {
+		cb, cn := buf.reset(num)
+		changes := 0
+		for i := from; i < to; i++ {
+			a := model.mapping[i]
+			full := !h.initialized
+			if !full {
+				if bound := max(h.s[a], h.lower[i]); h.upper[i] > bound {
+					h.upper[i] = t.distanceFn(data[i], centroids[a])
+					full = h.upper[i] > bound
+				}
+			}
+			if full {
+				first, second := math.Inf(1), math.Inf(1)
+				for j := range h.k {
+					d := t.distanceFn(data[i], centroids[j])
+					if d < first {
+						a, first, second = j, d, first
+					} else if d < second {
+						second = d
+					}
+				}
+				h.upper[i], h.lower[i] = first, second
+			}
+
+			if model.mapping[i] != a {
+				changes++
+			}
+			model.mapping[i] = a
+			dist[i] = h.upper[i]
+			w := model.weight(i)
+			cb[a] += w
+			floats.AddScaled(cn[a], w, data[i])
+		}
+		buf.changes[num] = changes
+	})
+	h.initialized = true
+
+	if h.previous == nil {
+		_, h.previous = prepare(h.k, len(centroids[0]))
+	}
+	for i := range centroids {
+		copy(h.previous[i], centroids[i])
+	}
+	return reduce(buf.cb, buf.cn, buf.changes, cb, cn)
+}
+
+// move adjust the bounds after the centroids were updated.
+func (h *hamerly) move(t Trainer, model *Model) {
+	shift := make([]float64, h.k)
+	// The lower bound of an observation moves by the largest shift among the other centroids.
+	largest, second := -1, -1
+	for i := range h.k {
+		shift[i] = t.distanceFn(h.previous[i], model.centroids[i])
+		if largest < 0 || shift[i] > shift[largest] {
+			largest, second = i, largest
+		} else if second < 0 || shift[i] > shift[second] {
+			second = i
+		}
+	}
+	for i := range h.upper {
+		a := model.mapping[i]
+		h.upper[i] += shift[a]
+		other := largest
+		if a == largest {
+			other = second
+		}
+		if other >= 0 {
+			h.lower[i] = max(h.lower[i]-shift[other], 0)
+		}
+	}
+}
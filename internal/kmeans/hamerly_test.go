@@ -0,0 +1,19 @@
+package kmeans
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHamerlyMatchesNaive(t *testing.T) {
+	data := blobs(100)
+	naive := fit(t, 5, data)
+	hamerly := fit(t, 5, data, WithHamerly())
+	if !slices.Equal(hamerly.Guesses(), naive.Guesses()) {
+		t.Errorf("WithHamerly() assignments differ from the naive assignments")
+	}
+}
+
+func BenchmarkLloydHamerly(b *testing.B) {
+	benchmarkLloyd(b, WithHamerly())
+}
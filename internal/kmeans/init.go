@@ -0,0 +1,118 @@
+package kmeans
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// InitMethod specify how the initial centroids are chosen.
+type InitMethod int
+
+const (
+	// KMeansPlusPlus pick initial centroids with probability proportional to their squared distance to the already picked ones.
+	KMeansPlusPlus InitMethod = iota
+	// Forgy pick k distinct random observations as initial centroids.
+	Forgy
+	// RandomPartition assign every observation to a random cluster and use the cluster means as initial centroids.
+	RandomPartition
+	// KMeansParallel use kmeans||, see WithParallelInit.
+	KMeansParallel
+	// GlobalKMeans use global kmeans, see WithGlobalInit.
+	GlobalKMeans
+)
+
+func (m InitMethod) String() string {
+	switch m {
+	case Forgy:
+		return "Forgy"
+	case RandomPartition:
+		return "RandomPartition"
+	case KMeansParallel:
+		return "KMeansParallel"
+	case GlobalKMeans:
+		return "GlobalKMeans"
+	default:
+		return "KMeansPlusPlus"
+	}
+}
+
+// WithInit specify the initialization method, default to KMeansPlusPlus.
+// KMeansParallel uses the default rounds and oversample unless configured with WithParallelInit.
+func WithInit(method InitMethod) TrainerOption {
+	return func(t *Trainer) {
+		t.init = method
+	}
+}
+
+// initialize choose the initial centroids of m using the configured method.
+func (t Trainer) initialize(ctx context.Context, m *Model, rng *rand.Rand) {
+	switch t.init {
+	case Forgy:
+		m.initializeFrom(m.forgy(rng))
+	case RandomPartition:
+		m.initializePartition(rng)
+		if t.spherical {
+			for i := range m.centroids {
+				normalize(m.centroids[i])
+			}
+		}
+	case KMeansParallel:
+		m.initializeFrom(t.seedParallel(m, rng))
+	case GlobalKMeans:
+		m.mapping = make([]int, len(m.data))
+		m.centroids = t.seedGlobal(ctx, m, rng)
+	default:
+		m.initializeFrom(m.seedGreedy(rng, t.greedy))
+	}
+}
+
+// forgy returns k distinct random observation indices.
+func (m *Model) forgy(rng *rand.Rand) []int {
+	// Partial Fisher-Yates shuffle.
+	perm := make([]int, len(m.data))
+	for i := range perm {
+		perm[i] = i
+	}
+	for i := 0; i < m.k; i++ {
+		j := i + rng.Intn(len(perm)-i)
+		perm[i], perm[j] = perm[j], perm[i]
+	}
+	return perm[:m.k]
+}
+
+// initializePartition use the means of a random partition of the observations as initial centroids.
+func (m *Model) initializePartition(rng *rand.Rand) {
+	cb, cn := prepare(m.k, len(m.data[0]))
+	m.mapping = make([]int, len(m.data))
+	for i := range m.data {
+		m.mapping[i] = rng.Intn(m.k)
+		w := m.weight(i)
+		cb[m.mapping[i]] += w
+		floats.AddScaled(cn[m.mapping[i]], w, m.data[i])
+	}
+
+	for i := range cn {
+		if cb[i] == 0 {
+			// Too few observations to fill every part, fallback to a random observation.
+			cn[i] = append(cn[i][:0], m.data[rng.Intn(len(m.data))]...)
+			continue
+		}
+		floats.Scale(1/cb[i], cn[i])
+	}
+	m.centroids = cn
+}
+
+// WithGreedyInit use greedy kmeans++: for each initial centroid, candidates observations are sampled
+// and the one reducing the inertia of the seeds the most is kept, which gives better initial centroids
+// for candidates times the seeding cost. Zero or less uses 2+floor(log(k)) candidates.
+func WithGreedyInit(candidates int) TrainerOption {
+	return func(t *Trainer) {
+		if candidates <= 0 {
+			candidates = 2 + int(math.Log(float64(max(t.k, 1))))
+		}
+		t.greedy = candidates
+	}
+}
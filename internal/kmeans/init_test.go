@@ -0,0 +1,33 @@
+package kmeans
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSeedDistinct(t *testing.T) {
+	var data Dataset
+	for range 100 {
+		data = append(data, []float64{0, 0}, []float64{0, 0}, []float64{0, 0}, []float64{5, 5}, []float64{9, 1})
+	}
+	for _, candidates := range []int{1, 3} {
+		for seed := range int64(50) {
+			m := &Model{data: data, k: 6, distanceFn: EuclideanDistance}
+			seeds := m.seedGreedy(rand.New(rand.NewSource(seed)), candidates)
+			sorted := slices.Clone(seeds)
+			slices.Sort(sorted)
+			if len(slices.Compact(sorted)) != len(seeds) {
+				t.Fatalf("seed %d: picked indices %v twice", seed, seeds)
+			}
+			// The first 3 seeds must cover the 3 distinct coordinates.
+			distinct := map[[2]float64]bool{}
+			for _, i := range seeds[:3] {
+				distinct[[2]float64(data[i])] = true
+			}
+			if len(distinct) != 3 {
+				t.Fatalf("seed %d: the first 3 seeds %v are not distinct coordinates", seed, seeds[:3])
+			}
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package kmeans
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// KernelFunc is a positive semi-definite kernel, the inner product of two observations in some feature space.
+type KernelFunc func(a, b []float64) float64
+
+// RBFKernel returns the gaussian kernel exp(-gamma·||a-b||²).
+func RBFKernel(gamma float64) KernelFunc {
+	return func(a, b []float64) float64 {
+		return math.Exp(-gamma * EuclideanDistanceSquared(a, b))
+	}
+}
+
+// KernelTrainer train kernel kmeans models, clustering in the feature space of a kernel using only kernel values,
+// so clusters do not need to be linearly separable. It needs the n×n kernel matrix, and each iteration is O(n²).
+// Distance, initialization and update options do not apply, the seed, iterations, delta threshold and concurrency do.
+type KernelTrainer struct {
+	Trainer
+	kernel KernelFunc
+}
+
+// KernelModel is a kernel kmeans model, it has no centroids as they live in the kernel feature space.
+type KernelModel struct {
+	kernel  KernelFunc
+	data    Dataset
+	k       int
+	mapping []int
+	sizes   []int
+	// self is the squared norm of each cluster mean in feature space: Σ K(j, l) / |c|² over members j, l.
+	self   []float64
+	iter   int
+	reason ConvergenceReason
+}
+
+// NewKernelTrainer create new KernelTrainer using kernel.
+func NewKernelTrainer(k int, kernel KernelFunc, options ...TrainerOption) KernelTrainer {
+	return KernelTrainer{Trainer: NewTrainer(k, options...), kernel: kernel}
+}
+
+// Fit create and train the *KernelModel.
+// It panics if the dataset or the trainer configuration is invalid, use TryFit to get an error instead.
+func (t KernelTrainer) Fit(data Dataset) *KernelModel {
+	m, err := t.TryFit(data)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryFit create and train the *KernelModel, returning an error if the dataset or the trainer configuration is invalid.
+func (t KernelTrainer) TryFit(data Dataset) (*KernelModel, error) {
+	return t.FitContext(context.Background(), data)
+}
+
+// FitContext create and train the *KernelModel, stopping early when ctx is done.
+func (t KernelTrainer) FitContext(ctx context.Context, data Dataset) (*KernelModel, error) {
+	if t.kernel == nil {
+		return nil, fmt.Errorf("%w: nil kernel", ErrUnknownDistance)
+	}
+	if err := t.validate(data); err != nil {
+		return nil, err
+	}
+	gram := make(Dataset, len(data))
+	for i := range gram {
+		gram[i] = make([]float64, len(data))
+	}
+	runChunks(t.workers(len(data)), len(data), func(_, from, to int) {
+		for i := from; i < to; i++ {
+			for j := range data {
+				gram[i][j] = t.kernel(data[i], data[j])
+			}
+		}
+	})
+	m, err := t.fit(ctx, gram)
+	if m != nil {
+		m.kernel, m.data = t.kernel, data
+	}
+	return m, err
+}
+
+// FitKernelMatrix create and train the *KernelModel from a precomputed kernel matrix,
+// entry [i][j] is the kernel between observations i and j. Predict is not available, use PredictKernel instead.
+// It panics if the matrix or the trainer configuration is invalid, use TryFitKernelMatrix to get an error instead.
+func (t KernelTrainer) FitKernelMatrix(gram Dataset) *KernelModel {
+	m, err := t.TryFitKernelMatrix(gram)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryFitKernelMatrix create and train the *KernelModel from a precomputed kernel matrix,
+// returning an error if the matrix or the trainer configuration is invalid.
+func (t KernelTrainer) TryFitKernelMatrix(gram Dataset) (*KernelModel, error) {
+	return t.FitKernelMatrixContext(context.Background(), gram)
+}
+
+// FitKernelMatrixContext create and train the *KernelModel from a precomputed kernel matrix,
+// stopping early when ctx is done.
+func (t KernelTrainer) FitKernelMatrixContext(ctx context.Context, gram Dataset) (*KernelModel, error) {
+	for i := range gram {
+		if len(gram[i]) != len(gram) {
+			return nil, fmt.Errorf("%w: kernel matrix row %d has %d entries, expected %d", ErrDimensionMismatch, i, len(gram[i]), len(gram))
+		}
+	}
+	if err := t.validate(gram); err != nil {
+		return nil, err
+	}
+	return t.fit(ctx, gram)
+}
+
+func (t KernelTrainer) fit(ctx context.Context, gram Dataset) (*KernelModel, error) {
+	n := len(gram)
+	m := &KernelModel{k: t.k, mapping: make([]int, n), sizes: make([]int, t.k), self: make([]float64, t.k)}
+	rng := t.newRand()
+	seeds := kernelSeed(gram, t.k, rng)
+	for i := range gram {
+		m.mapping[i] = 0
+		for c, s := range seeds {
+			if kernelDistance(gram, i, s) < kernelDistance(gram, i, seeds[m.mapping[i]]) {
+				m.mapping[i] = c
+			}
+		}
+	}
+
+	changeThreshold := int(float64(n) * t.delta)
+	next := make([]int, n)
+	dist := make([]float64, n)
+	m.reason = ReasonMaxIterations
+	var err error
+	for ; m.iter < t.maxIterations; m.iter++ {
+		if err = ctx.Err(); err != nil {
+			m.reason = ReasonCancelled
+			break
+		}
+		m.summarize(gram)
+
+		// Reassign each observation to the cluster whose feature space mean is the nearest.
+		workers := t.workers(n)
+		ichanges := make([]int, workers)
+		runChunks(workers, n, func(num, from, to int) {
+			sums := make([]float64, t.k)
+			for i := from; i < to; i++ {
+				clear(sums)
+				for j, c := range m.mapping {
+					sums[c] += gram[i][j]
+				}
+				next[i], dist[i] = m.nearest(gram[i][i], sums)
+				if next[i] != m.mapping[i] {
+					ichanges[num]++
+				}
+			}
+		})
+		copy(m.mapping, next)
+		m.reinitialize(dist)
+
+		changes := 0
+		for _, c := range ichanges {
+			changes += c
+		}
+		if changes == 0 || changes < changeThreshold {
+			m.reason = ReasonChangesStable
+			m.iter++
+			break
+		}
+	}
+	m.summarize(gram)
+	return m, err
+}
+
+// kernelDistance returns the squared feature space distance between observations i and j.
+func kernelDistance(gram Dataset, i, j int) float64 {
+	return gram[i][i] + gram[j][j] - 2*gram[i][j]
+}
+
+// kernelSeed returns the indices of k distinct observations picked by kmeans++ in feature space.
+func kernelSeed(gram Dataset, k int, rng *rand.Rand) []int {
+	n := len(gram)
+	seeds := []int{rng.Intn(n)}
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = kernelDistance(gram, i, seeds[0])
+	}
+	for len(seeds) < k {
+		s := float64(0)
+		for i := range d {
+			d[i] = max(d[i], 0)
+			if slices.Contains(seeds, i) {
+				d[i] = 0
+			}
+			s += d[i]
+		}
+
+		next := -1
+		if s > 0 {
+			t := rng.Float64() * s
+			for i := range d {
+				if t -= d[i]; t <= 0 && d[i] > 0 {
+					next = i
+					break
+				}
+			}
+		}
+		if next < 0 {
+			// Every observation lies on a seed, or rounding, fallback to the first remaining one.
+			for i := range d {
+				if !slices.Contains(seeds, i) {
+					next = i
+					break
+				}
+			}
+		}
+		seeds = append(seeds, next)
+		for i := range d {
+			d[i] = min(d[i], kernelDistance(gram, i, next))
+		}
+	}
+	return seeds
+}
+
+// summarize compute the sizes and the feature space squared norm of each cluster mean.
+func (m *KernelModel) summarize(gram Dataset) {
+	clear(m.sizes)
+	clear(m.self)
+	for i, c := range m.mapping {
+		m.sizes[c]++
+		for j, o := range m.mapping {
+			if o == c {
+				m.self[c] += gram[i][j]
+			}
+		}
+	}
+	for c, s := range m.sizes {
+		if s > 0 {
+			m.self[c] /= float64(s * s)
+		}
+	}
+}
+
+// nearest returns the nearest non-empty cluster of an observation and its squared feature space distance,
+// from its kernel with itself and the sums of its kernel with the members of each cluster.
+func (m *KernelModel) nearest(self float64, sums []float64) (int, float64) {
+	best, bd := -1, math.Inf(1)
+	for c := range m.k {
+		if m.sizes[c] == 0 {
+			continue
+		}
+		if d := self - 2*sums[c]/float64(m.sizes[c]) + m.self[c]; d < bd {
+			best, bd = c, d
+		}
+	}
+	return best, bd
+}
+
+// reinitialize move the observation farthest from its cluster into each empty cluster.
+func (m *KernelModel) reinitialize(dist []float64) {
+	sizes := make([]int, m.k)
+	for _, c := range m.mapping {
+		sizes[c]++
+	}
+	for c := range m.k {
+		if sizes[c] > 0 {
+			continue
+		}
+		p := -1
+		for i := range dist {
+			if sizes[m.mapping[i]] > 1 && (p < 0 || dist[i] > dist[p]) {
+				p = i
+			}
+		}
+		if p < 0 {
+			return
+		}
+		sizes[m.mapping[p]]--
+		m.mapping[p] = c
+		sizes[c]++
+		dist[p] = 0
+	}
+}
+
+// Predict returns number of cluster to which the observation would be assigned, in [0, k).
+// It panics with ErrNotFitted if the model was trained from a kernel matrix.
+func (m *KernelModel) Predict(p []float64) int {
+	if m.kernel == nil {
+		panic(fmt.Errorf("%w: trained from a kernel matrix, use PredictKernel", ErrNotFitted))
+	}
+	row := make([]float64, len(m.data))
+	for j := range m.data {
+		row[j] = m.kernel(p, m.data[j])
+	}
+	return m.predict(m.kernel(p, p), row)
+}
+
+// PredictKernel returns number of cluster to which an observation would be assigned,
+// from its kernel with itself and its kernel with each training observation.
+// It panics with ErrDimensionMismatch if row does not have one entry per training observation.
+func (m *KernelModel) PredictKernel(self float64, row []float64) int {
+	if len(row) != len(m.mapping) {
+		panic(fmt.Errorf("%w: got %d kernel values for %d training observations", ErrDimensionMismatch, len(row), len(m.mapping)))
+	}
+	return m.predict(self, row)
+}
+
+func (m *KernelModel) predict(self float64, row []float64) int {
+	sums := make([]float64, m.k)
+	for j, c := range m.mapping {
+		sums[c] += row[j]
+	}
+	c, _ := m.nearest(self, sums)
+	return c
+}
+
+// Guesses returns a copy of the cluster number of each training observation.
+func (m *KernelModel) Guesses() []int {
+	return slices.Clone(m.mapping)
+}
+
+// Sizes returns a copy of the number of observations assigned to each cluster.
+func (m *KernelModel) Sizes() []int {
+	return slices.Clone(m.sizes)
+}
+
+// Iter returns model number of iterations run.
+func (m *KernelModel) Iter() int {
+	return m.iter
+}
+
+// ConvergedReason returns why the training stopped.
+func (m *KernelModel) ConvergedReason() ConvergenceReason {
+	return m.reason
+}
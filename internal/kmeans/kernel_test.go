@@ -0,0 +1,46 @@
+package kmeans
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFitKernelMatrixMatchesFit(t *testing.T) {
+	data := blobs(20)
+	kernel := RBFKernel(0.1)
+	tr := NewKernelTrainer(3, kernel, WithSeed(1))
+	want := tr.Fit(data)
+
+	gram := make(Dataset, len(data))
+	for i := range gram {
+		gram[i] = make([]float64, len(data))
+		for j := range data {
+			gram[i][j] = kernel(data[i], data[j])
+		}
+	}
+	got, err := tr.TryFitKernelMatrix(gram)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Guesses(), want.Guesses()) {
+		t.Errorf("kernel matrix guesses %v, expected %v", got.Guesses(), want.Guesses())
+	}
+	if !slices.Equal(tr.FitKernelMatrix(gram).Guesses(), want.Guesses()) {
+		t.Error("FitKernelMatrix and TryFitKernelMatrix disagree")
+	}
+}
+
+func TestFitKernelMatrixNotSquare(t *testing.T) {
+	tr := NewKernelTrainer(2, RBFKernel(1))
+	gram := Dataset{{1, 0}, {0, 1}, {0, 0}}
+	if _, err := tr.TryFitKernelMatrix(gram); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("TryFitKernelMatrix error %v, expected ErrDimensionMismatch", err)
+	}
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrDimensionMismatch) {
+			t.Errorf("FitKernelMatrix panicked with %v, expected ErrDimensionMismatch", err)
+		}
+	}()
+	tr.FitKernelMatrix(gram)
+}
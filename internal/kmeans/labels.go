@@ -0,0 +1,53 @@
+package kmeans
+
+import (
+	"cmp"
+	"slices"
+)
+
+// WithCanonicalLabels number clusters by the lexicographic order of their centroids once trained,
+// so the same data gives the same labels across runs regardless of the initialization.
+func WithCanonicalLabels() TrainerOption {
+	return func(t *Trainer) {
+		t.canonical = true
+	}
+}
+
+// canonicalize renumber the clusters by the lexicographic order of their centroids,
+// returning perm such that new cluster i was cluster perm[i].
+func (m *Model) canonicalize() []int {
+	perm := make([]int, len(m.centroids))
+	for i := range perm {
+		perm[i] = i
+	}
+	slices.SortStableFunc(perm, func(a, b int) int {
+		return slices.Compare(m.centroids[a], m.centroids[b])
+	})
+
+	labels := make([]int, len(perm))
+	centroids := make(Dataset, len(perm))
+	for i, p := range perm {
+		labels[p] = i
+		centroids[i] = m.centroids[p]
+	}
+	m.centroids = centroids
+	for i := range m.mapping {
+		m.mapping[i] = labels[m.mapping[i]]
+	}
+	m.sizes = permute(m.sizes, perm)
+	m.counts = permute(m.counts, perm)
+	m.spreads = permute(m.spreads, perm)
+	return perm
+}
+
+// permute returns s reordered so that element i is s[perm[i]], or nil if s is nil.
+func permute[T cmp.Ordered](s []T, perm []int) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, len(s))
+	for i, p := range perm {
+		out[i] = s[p]
+	}
+	return out
+}
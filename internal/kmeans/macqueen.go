@@ -0,0 +1,109 @@
+package kmeans
+
+import (
+	"context"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// UpdateMode specify when centroids are updated during training.
+type UpdateMode int
+
+const (
+	// Lloyd recompute every centroid after each full assignment pass.
+	Lloyd UpdateMode = iota
+	// MacQueen move both centroids involved as soon as an observation changes cluster,
+	// which often needs fewer passes but is sequential.
+	MacQueen
+)
+
+// WithUpdateMode specify when centroids are updated, default to Lloyd.
+// MacQueen does not support WithMedianUpdate, WithMinClusterSize nor WithConstraints, training fails with ErrIncompatibleOptions,
+// and it ignores WithElkan and WithHamerly.
+func WithUpdateMode(mode UpdateMode) TrainerOption {
+	return func(t *Trainer) {
+		t.update = mode
+	}
+}
+
+// macQueen run the MacQueen passes on an initialized model, returns the number of passes.
+func (t Trainer) macQueen(ctx context.Context, model *Model) (int, error) {
+	l := len(model.centroids[0])
+	changeThreshold := int(float64(len(model.data)) * t.delta)
+	distanceFn, _ := compareFn(t.distanceFn)
+
+	// The first assignment makes every centroid the mean of its members, kept up to date from there.
+	cb, cn := prepare(t.k, l)
+	buf := t.newAssignBuffers(len(model.data), l)
+	t.assign(model, make([]float64, len(model.data)), cb, cn, buf)
+	buf.close()
+	for i := range cb {
+		if cb[i] > 0 {
+			floats.ScaleTo(model.centroids[i], 1/cb[i], cn[i])
+		}
+	}
+
+	_, previous := prepare(t.k, l)
+	model.reason = ReasonMaxIterations
+	iter := 0
+	for ; iter < t.maxIterations; iter++ {
+		if err := ctx.Err(); err != nil {
+			model.reason = ReasonCancelled
+			return iter, err
+		}
+		for i := range previous {
+			copy(previous[i], model.centroids[i])
+		}
+
+		changes := 0
+		for i, p := range model.data {
+			n, m := 0, distanceFn(p, model.centroids[0])
+			for j := 1; j < t.k; j++ {
+				if d := distanceFn(p, model.centroids[j]); d < m {
+					n, m = j, d
+				}
+			}
+			a := model.mapping[i]
+			if n == a {
+				continue
+			}
+
+			changes++
+			model.mapping[i] = n
+			w := model.weight(i)
+			if w == 0 {
+				continue
+			}
+			// Remove p from the mean of a, keeping the last centroid of a cluster that becomes empty.
+			if rest := cb[a] - w; rest > 0 {
+				floats.Scale(cb[a]/rest, model.centroids[a])
+				floats.AddScaled(model.centroids[a], -w/rest, p)
+			}
+			cb[a] = max(cb[a]-w, 0)
+			cb[n] += w
+			for j := range model.centroids[n] {
+				model.centroids[n][j] += w / cb[n] * (p[j] - model.centroids[n][j])
+			}
+		}
+		if t.spherical {
+			for i := range model.centroids {
+				normalize(model.centroids[i])
+			}
+		}
+
+		model.shift = model.centroidShift(previous)
+		if !t.observe(model, iter, changes) {
+			model.reason = ReasonCallback
+			return iter + 1, nil
+		}
+		if changes == 0 || changes < changeThreshold {
+			model.reason = ReasonChangesStable
+			return iter + 1, nil
+		}
+		if model.shift < t.tolerance {
+			model.reason = ReasonToleranceMet
+			return iter + 1, nil
+		}
+	}
+	return iter, nil
+}
@@ -0,0 +1,23 @@
+package kmeans
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMacQueenUnsupportedOptions(t *testing.T) {
+	data := blobs(10)
+	options := map[string]TrainerOption{
+		"WithMedianUpdate":   WithMedianUpdate(),
+		"WithMinClusterSize": WithMinClusterSize(3),
+		"WithConstraints":    WithConstraints([][2]int{{0, 1}}, [][2]int{{0, 2}}),
+	}
+	for name, option := range options {
+		if _, err := NewTrainer(2, WithUpdateMode(MacQueen), option).TryFit(data); !errors.Is(err, ErrIncompatibleOptions) {
+			t.Errorf("MacQueen with %s: error %v, expected ErrIncompatibleOptions", name, err)
+		}
+	}
+	if _, err := NewTrainer(3, WithSeed(1), WithUpdateMode(MacQueen)).TryFit(data); err != nil {
+		t.Errorf("MacQueen: %v", err)
+	}
+}
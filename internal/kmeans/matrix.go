@@ -0,0 +1,30 @@
+package kmeans
+
+import "gonum.org/v1/gonum/mat"
+
+// FitMatrix create and train the *Model using the rows of m as observations.
+// Rows of a *mat.Dense are used without being copied.
+func (t Trainer) FitMatrix(m mat.Matrix) (*Model, error) {
+	return t.TryFit(rows(m))
+}
+
+// PredictMatrix returns number of cluster to which each row of m would be assigned.
+func (m *Model) PredictMatrix(x mat.Matrix) ([]int, error) {
+	return m.PredictBatch(rows(x))
+}
+
+// rows returns the rows of m as a Dataset, sharing the backing array of a *mat.Dense.
+func rows(m mat.Matrix) Dataset {
+	r, _ := m.Dims()
+	data := make(Dataset, r)
+	if d, ok := m.(mat.RawRowViewer); ok {
+		for i := range data {
+			data[i] = d.RawRowView(i)
+		}
+		return data
+	}
+	for i := range data {
+		data[i] = mat.Row(nil, i, m)
+	}
+	return data
+}
@@ -0,0 +1,95 @@
+package kmeans
+
+import (
+	"gonum.org/v1/gonum/floats"
+	"slices"
+	"sort"
+)
+
+// WithMedianUpdate train using k-medians: each centroid coordinate is updated to the median
+// of its cluster observations instead of the mean, which resists outliers.
+// It pairs naturally with ManhattanDistance.
+func WithMedianUpdate() TrainerOption {
+	return func(t *Trainer) {
+		t.median = true
+	}
+}
+
+// updateMedians set each non-empty cluster centroid to the coordinate-wise median of its observations.
+func updateMedians(m *Model) {
+	members := make([][]int, m.k)
+	for i, c := range m.mapping {
+		members[c] = append(members[c], i)
+	}
+
+	var (
+		values  []float64
+		weights []float64
+	)
+	for c, idx := range members {
+		if len(idx) == 0 {
+			continue
+		}
+		for j := range m.centroids[c] {
+			values = values[:0]
+			weights = weights[:0]
+			for _, i := range idx {
+				values = append(values, m.data[i][j])
+				weights = append(weights, m.weight(i))
+			}
+			if m.weights == nil {
+				m.centroids[c][j] = median(values)
+			} else {
+				m.centroids[c][j] = weightedMedian(values, weights)
+			}
+		}
+	}
+}
+
+// median returns the median of values, the average of the two middle values for even length.
+// The values are reordered.
+func median(values []float64) float64 {
+	slices.Sort(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
+
+// weightedMedian returns the value splitting the total weight in half,
+// the average of the two values around the split when it falls exactly between them.
+// The values and weights are reordered.
+func weightedMedian(values, weights []float64) float64 {
+	sort.Sort(byValue{values, weights})
+	half := floats.Sum(weights) / 2
+	s := float64(0)
+	for i := range values {
+		s += weights[i]
+		if s > half {
+			return values[i]
+		}
+		if s == half {
+			for j := i + 1; j < len(values); j++ {
+				if weights[j] > 0 {
+					return (values[i] + values[j]) / 2
+				}
+			}
+			return values[i]
+		}
+	}
+	return values[len(values)-1]
+}
+
+// byValue sort values along with their weights.
+type byValue struct {
+	values  []float64
+	weights []float64
+}
+
+func (b byValue) Len() int           { return len(b.values) }
+func (b byValue) Less(i, j int) bool { return b.values[i] < b.values[j] }
+func (b byValue) Swap(i, j int) {
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+	b.weights[i], b.weights[j] = b.weights[j], b.weights[i]
+}
@@ -0,0 +1,25 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMedianUpdateResistsOutlier(t *testing.T) {
+	data := Dataset{{1}, {2}, {3}, {4}, {1000}}
+	median := fit(t, 1, data, WithMedianUpdate(), WithDistance("ManhattanDistance"))
+	if got := median.Cluster(0)[0]; got != 3 {
+		t.Errorf("median centroid = %v, want 3", got)
+	}
+	mean := fit(t, 1, data)
+	if got := mean.Cluster(0)[0]; got != 202 {
+		t.Errorf("mean centroid = %v, want 202", got)
+	}
+}
+
+func TestMedianUpdateEvenCluster(t *testing.T) {
+	m := fit(t, 1, Dataset{{1, 10}, {2, 20}, {4, 30}, {100, 40}}, WithMedianUpdate())
+	if got := m.Cluster(0); math.Abs(got[0]-3) > 1e-12 || math.Abs(got[1]-25) > 1e-12 {
+		t.Errorf("median centroid = %v, want [3 25]", got)
+	}
+}
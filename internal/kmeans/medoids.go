@@ -0,0 +1,164 @@
+package kmeans
+
+import (
+	"context"
+	"math"
+	"slices"
+)
+
+// MedoidsTrainer train k-medoids models using the PAM algorithm, where each cluster center is an actual observation.
+// Since centers are never averaged, it works with any DistanceFunc, including CosineDistance and custom ones,
+// and is more robust to outliers than kmeans.
+// Each iteration of the swap phase computes O(n²) distances.
+type MedoidsTrainer struct {
+	Trainer
+}
+
+// MedoidsModel is a k-medoids model, its centroids are copies of the medoids.
+type MedoidsModel struct {
+	view
+	medoids []int
+}
+
+// NewMedoidsTrainer create new MedoidsTrainer.
+func NewMedoidsTrainer(k int, options ...TrainerOption) MedoidsTrainer {
+	return MedoidsTrainer{Trainer: NewTrainer(k, options...)}
+}
+
+// Fit create and train the *MedoidsModel.
+// It panics if the dataset or the trainer configuration is invalid, use TryFit to get an error instead.
+func (t MedoidsTrainer) Fit(data Dataset) *MedoidsModel {
+	m, err := t.TryFit(data)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryFit create and train the *MedoidsModel, returning an error if the dataset or the trainer configuration is invalid.
+func (t MedoidsTrainer) TryFit(data Dataset) (*MedoidsModel, error) {
+	return t.FitContext(context.Background(), data)
+}
+
+// FitContext create and train the *MedoidsModel, stopping early when ctx is done.
+func (t MedoidsTrainer) FitContext(ctx context.Context, data Dataset) (*MedoidsModel, error) {
+	if err := t.validate(data); err != nil {
+		return nil, err
+	}
+
+	model := &Model{data: data, k: t.k, distanceFn: t.distanceFn, distanceName: t.distanceName, mapping: make([]int, len(data))}
+	mm := &MedoidsModel{view: view{model: model}, medoids: model.seedGreedy(t.newRand(), t.greedy)}
+	nearest := make([]float64, len(data))
+	second := make([]float64, len(data))
+
+	var err error
+	iter := 0
+	for ; iter < t.maxIterations; iter++ {
+		if err = ctx.Err(); err != nil {
+			model.reason = ReasonCancelled
+			break
+		}
+		mm.nearest(nearest, second)
+		if !t.swap(mm, nearest, second) {
+			model.reason = ReasonChangesStable
+			iter++
+			break
+		}
+	}
+
+	mm.nearest(nearest, second)
+	model.centroids = make(Dataset, t.k)
+	for i, p := range mm.medoids {
+		model.centroids[i] = append([]float64(nil), data[p]...)
+	}
+	model.iter = iter
+	model.summarize()
+	if t.canonical {
+		mm.medoids = permute(mm.medoids, model.canonicalize())
+	}
+	return mm, err
+}
+
+// nearest assign each observation to its nearest medoid,
+// storing the distance to its nearest and second nearest medoids.
+func (mm *MedoidsModel) nearest(nearest, second []float64) {
+	for i := range mm.model.data {
+		nearest[i], second[i] = math.Inf(1), math.Inf(1)
+		for c, p := range mm.medoids {
+			d := mm.model.distanceFn(mm.model.data[i], mm.model.data[p])
+			switch {
+			case d < nearest[i]:
+				second[i] = nearest[i]
+				nearest[i] = d
+				mm.model.mapping[i] = c
+			case d < second[i]:
+				second[i] = d
+			}
+		}
+	}
+}
+
+// swap perform the best swap between a medoid and a non-medoid observation that reduces the total cost,
+// returns false if no swap improves it.
+func (t MedoidsTrainer) swap(mm *MedoidsModel, nearest, second []float64) bool {
+	data := mm.model.data
+	isMedoid := make([]bool, len(data))
+	for _, p := range mm.medoids {
+		isMedoid[p] = true
+	}
+
+	type candidate struct {
+		delta   float64
+		cluster int
+		point   int
+	}
+	workers := t.workers(len(data))
+	best := make([]candidate, workers)
+	runChunks(workers, len(data), func(num, from, to int) {
+		best[num] = candidate{cluster: -1}
+		delta := make([]float64, t.k)
+		for o := from; o < to; o++ {
+			if isMedoid[o] {
+				continue
+			}
+			// Cost change of replacing each medoid by o.
+			shared := float64(0)
+			clear(delta)
+			for i := range data {
+				d := t.distanceFn(data[i], data[o])
+				c := mm.model.mapping[i]
+				if d < nearest[i] {
+					// i moves to o whichever medoid is replaced.
+					shared += d - nearest[i]
+					continue
+				}
+				// i only moves if its own medoid is removed.
+				delta[c] += min(d, second[i]) - nearest[i]
+			}
+			for c := range delta {
+				if dc := delta[c] + shared; dc < best[num].delta {
+					best[num] = candidate{delta: dc, cluster: c, point: o}
+				}
+			}
+		}
+	})
+
+	b := candidate{cluster: -1}
+	for _, c := range best {
+		if c.cluster >= 0 && c.delta < b.delta {
+			b = c
+		}
+	}
+	if b.cluster < 0 {
+		return false
+	}
+	mm.medoids[b.cluster] = b.point
+	return true
+}
+
+// Medoids returns a copy of the indices of the observations chosen as cluster centers.
+func (mm *MedoidsModel) Medoids() []int {
+	mm.model.mu.RLock()
+	defer mm.model.mu.RUnlock()
+	return slices.Clone(mm.medoids)
+}
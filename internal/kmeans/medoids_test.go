@@ -0,0 +1,67 @@
+package kmeans
+
+import (
+	"slices"
+	"testing"
+)
+
+// medoidsCost returns the sum of the distances between each observation and its nearest medoid.
+func medoidsCost(data Dataset, medoids []int) float64 {
+	s := float64(0)
+	for _, p := range data {
+		d := EuclideanDistance(p, data[medoids[0]])
+		for _, c := range medoids[1:] {
+			d = min(d, EuclideanDistance(p, data[c]))
+		}
+		s += d
+	}
+	return s
+}
+
+func TestMedoidsSwapsLowerCost(t *testing.T) {
+	data := blobs(20)
+	tr := NewMedoidsTrainer(3, WithSeed(1))
+	model := &Model{data: data, k: 3, distanceFn: EuclideanDistance, mapping: make([]int, len(data))}
+	mm := &MedoidsModel{view: view{model: model}, medoids: model.seedGreedy(tr.newRand(), tr.greedy)}
+	nearest, second := make([]float64, len(data)), make([]float64, len(data))
+	cost := medoidsCost(data, mm.medoids)
+	swaps := 0
+	for ; ; swaps++ {
+		mm.nearest(nearest, second)
+		if !tr.swap(mm, nearest, second) {
+			break
+		}
+		next := medoidsCost(data, mm.medoids)
+		if next > cost+1e-9 {
+			t.Fatalf("swap %d raised the cost from %v to %v", swaps, cost, next)
+		}
+		cost = next
+	}
+	if swaps == 0 {
+		t.Error("no swap improved the initial medoids")
+	}
+}
+
+func TestMedoidsAreObservations(t *testing.T) {
+	data := blobs(20)
+	m := NewMedoidsTrainer(3, WithSeed(1)).Fit(data)
+	medoids, centroids := m.Medoids(), m.Centroids()
+	if len(medoids) != 3 {
+		t.Fatalf("%d medoids, expected 3", len(medoids))
+	}
+	for c, p := range medoids {
+		if !slices.Equal(data[p], centroids[c]) {
+			t.Errorf("centroid %d is %v, expected the medoid observation %d %v", c, centroids[c], p, data[p])
+		}
+		if m.Guesses()[p] != c {
+			t.Errorf("medoid %d is assigned to cluster %d, expected %d", p, m.Guesses()[p], c)
+		}
+	}
+	truth := make([]int, len(data))
+	for i := range truth {
+		truth[i] = i / 20
+	}
+	if ari, _ := AdjustedRandIndex(m.Guesses(), truth); ari != 1 {
+		t.Errorf("adjusted rand index %v, expected 1", ari)
+	}
+}
@@ -0,0 +1,66 @@
+package kmeans
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Merge pools the centroids of models trained on separate shards of a dataset and clusters them into finalK clusters,
+// weighting each centroid by its cluster size, so data never has to be centralized.
+// The merged model is trained by a Trainer configured by options, using the distance of the models.
+// Models must share the same dimension and distance: registered distances are compared by name,
+// and models trained with WithDistanceFunc by function. Closures returned by the same function,
+// such as MinkowskiDistance(1) and MinkowskiDistance(3), cannot be told apart and are assumed to be the same distance.
+// Models without training sizes, such as decoded ones, count each centroid as a single observation.
+// The Guesses of the merged model map the pooled centroids, in models order, to their cluster.
+func Merge(models []*Model, finalK int, options ...TrainerOption) (*Model, error) {
+	if len(models) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	var (
+		pooled  Dataset
+		weights []float64
+	)
+	for i, m := range models {
+		centroids, sizes := m.Centroids(), m.Sizes()
+		if len(centroids) == 0 {
+			return nil, fmt.Errorf("%w: model %d", ErrNotFitted, i)
+		}
+		if i > 0 && len(centroids[0]) != len(pooled[0]) {
+			return nil, fmt.Errorf("%w: model %d has %d dimensions, expected %d", ErrDimensionMismatch, i, len(centroids[0]), len(pooled[0]))
+		}
+		if !sameDistance(m, models[0]) {
+			return nil, fmt.Errorf("%w: model %d uses %s, expected %s", ErrDistanceMismatch, i, describeDistance(m), describeDistance(models[0]))
+		}
+		for c, centroid := range centroids {
+			pooled = append(pooled, centroid)
+			if sizes == nil {
+				weights = append(weights, 1)
+			} else {
+				weights = append(weights, float64(sizes[c]))
+			}
+		}
+	}
+
+	t := NewTrainer(finalK, options...)
+	t.distanceFn = models[0].distanceFn
+	t.distanceName = models[0].distanceName
+	return t.FitWeighted(pooled, weights)
+}
+
+// sameDistance tells whether a and b use the same distance, by name when both are registered, by function otherwise.
+func sameDistance(a, b *Model) bool {
+	if a.distanceName != "" && b.distanceName != "" {
+		return a.distanceName == b.distanceName
+	}
+	return reflect.ValueOf(a.distanceFn).Pointer() == reflect.ValueOf(b.distanceFn).Pointer()
+}
+
+// describeDistance returns the quoted name of the distance of m, for error messages.
+func describeDistance(m *Model) string {
+	if m.distanceName == "" {
+		return "an unregistered distance function"
+	}
+	return fmt.Sprintf("%q", m.distanceName)
+}
@@ -0,0 +1,69 @@
+package kmeans
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// shards returns the models fitted with options on n strided shards of data.
+func shards(t *testing.T, data Dataset, n int, options ...TrainerOption) []*Model {
+	t.Helper()
+	models := make([]*Model, n)
+	for s := range models {
+		var shard Dataset
+		for i := s; i < len(data); i += n {
+			shard = append(shard, data[i])
+		}
+		models[s] = fit(t, 4, shard, options...)
+	}
+	return models
+}
+
+func TestMerge(t *testing.T) {
+	data := blobs(30)
+	merged, err := Merge(shards(t, data, 3), 3, WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fit(t, 3, data)
+	for _, c := range want.Centroids() {
+		_, d := merged.PredictWithDistance(c)
+		if d > 0.5 {
+			t.Errorf("merged model is %v away from centroid %v", d, c)
+		}
+	}
+}
+
+func TestMergeDistanceFunc(t *testing.T) {
+	data := blobs(30)
+	custom := func(a, b []float64) float64 { return ManhattanDistance(a, b) }
+	if _, err := Merge(shards(t, data, 2, WithDistanceFunc(custom)), 3); err != nil {
+		t.Errorf("merging models sharing a distance function: %v", err)
+	}
+
+	other := func(a, b []float64) float64 { return math.Sqrt(EuclideanDistance(a, b)) }
+	models := append(shards(t, data, 1, WithDistanceFunc(custom)), shards(t, data, 1, WithDistanceFunc(other))...)
+	if _, err := Merge(models, 3); !errors.Is(err, ErrDistanceMismatch) {
+		t.Errorf("merging models with different distance functions: error %v, expected ErrDistanceMismatch", err)
+	}
+
+	models = append(shards(t, data, 1), shards(t, data, 1, WithDistance("ManhattanDistance"))...)
+	if _, err := Merge(models, 3); !errors.Is(err, ErrDistanceMismatch) {
+		t.Errorf("merging models with different distances: error %v, expected ErrDistanceMismatch", err)
+	}
+}
+
+func TestMergeInvalid(t *testing.T) {
+	models := shards(t, blobs(10), 1)
+	if _, err := Merge(nil, 3); !errors.Is(err, ErrEmptyDataset) {
+		t.Errorf("merging no model: error %v, expected ErrEmptyDataset", err)
+	}
+	if _, err := Merge(append(models, &Model{}), 3); !errors.Is(err, ErrNotFitted) {
+		t.Errorf("merging an unfitted model: error %v, expected ErrNotFitted", err)
+	}
+	flat := fit(t, 2, Dataset{{0, 0, 0}, {1, 1, 1}, {5, 5, 5}})
+	if _, err := Merge(append(models, flat), 3); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("merging models of different dimensions: error %v, expected ErrDimensionMismatch", err)
+	}
+}
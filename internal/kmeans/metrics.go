@@ -0,0 +1,195 @@
+package kmeans
+
+import (
+	"fmt"
+	"gonum.org/v1/gonum/floats"
+	"math"
+)
+
+// Inertia returns the within-cluster sum of squares,
+// the sum of squared euclidean distances between each observation and its centroid, scaled by the observation weight.
+func (m *Model) Inertia() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inertia
+}
+
+func (m *Model) computeInertia() float64 {
+	s := float64(0)
+	for i := range m.data {
+		s += m.weight(i) * EuclideanDistanceSquared(m.data[i], m.centroids[m.mapping[i]])
+	}
+	return s
+}
+
+// SilhouetteScore returns the mean silhouette coefficient of the training observations, in [-1, 1], higher is better.
+// As the computation is quadratic, when sample is positive and less than the number of observations,
+// the score is estimated on sample evenly spaced observations. It is 0 with less than 2 clusters.
+// It returns ErrNotFitted for models without training data, such as loaded ones.
+func (m *Model) SilhouetteScore(sample int) (float64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.requireData(); err != nil {
+		return 0, err
+	}
+	if m.k < 2 {
+		return 0, nil
+	}
+
+	sizes := m.sizes
+	step := 1
+	if sample > 0 && sample < len(m.data) {
+		step = len(m.data) / sample
+	}
+
+	sums := make([]float64, m.k)
+	s := float64(0)
+	n := 0
+	for i := 0; i < len(m.data); i += step {
+		n++
+		own := m.mapping[i]
+		if sizes[own] < 2 {
+			continue
+		}
+
+		clear(sums)
+		for j := range m.data {
+			sums[m.mapping[j]] += m.distanceFn(m.data[i], m.data[j])
+		}
+
+		a := sums[own] / float64(sizes[own]-1)
+		b := math.Inf(1)
+		for c := range m.k {
+			if c == own || sizes[c] == 0 {
+				continue
+			}
+			b = min(b, sums[c]/float64(sizes[c]))
+		}
+		if math.IsInf(b, 1) {
+			continue
+		}
+		if d := max(a, b); d > 0 {
+			s += (b - a) / d
+		}
+	}
+	return s / float64(n), nil
+}
+
+// DaviesBouldinIndex returns the average similarity between each cluster and its most similar one,
+// where similarity is the ratio of within-cluster scatter to between-centroid distance, lower is better.
+// Pairs of coinciding centroids are skipped as their similarity is undefined.
+// It returns ErrNotFitted for models without training data, such as loaded ones.
+func (m *Model) DaviesBouldinIndex() (float64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.requireData(); err != nil {
+		return 0, err
+	}
+	sizes := m.sizes
+	scatter := make([]float64, m.k)
+	for i := range m.data {
+		c := m.mapping[i]
+		scatter[c] += m.distanceFn(m.data[i], m.centroids[c])
+	}
+
+	s := float64(0)
+	n := 0
+	for i := range m.k {
+		if sizes[i] == 0 {
+			continue
+		}
+		n++
+		worst := float64(0)
+		for j := range m.k {
+			if j == i || sizes[j] == 0 {
+				continue
+			}
+			d := m.distanceFn(m.centroids[i], m.centroids[j])
+			if d == 0 {
+				continue
+			}
+			r := (scatter[i]/float64(sizes[i]) + scatter[j]/float64(sizes[j])) / d
+			worst = max(worst, r)
+		}
+		s += worst
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return s / float64(n), nil
+}
+
+// CalinskiHarabaszScore returns the ratio of between-cluster dispersion to within-cluster dispersion,
+// scaled by (n-k)/(k-1), higher is better. It is 0 with less than 2 clusters or no more observations than clusters.
+// It returns ErrNotFitted for models without training data, such as loaded ones.
+func (m *Model) CalinskiHarabaszScore() (float64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.requireData(); err != nil {
+		return 0, err
+	}
+	n := len(m.data)
+	if m.k < 2 || n <= m.k {
+		return 0, nil
+	}
+
+	mean := make([]float64, len(m.data[0]))
+	for i := range m.data {
+		floats.Add(mean, m.data[i])
+	}
+	floats.Scale(1/float64(n), mean)
+
+	between := float64(0)
+	for c := range m.k {
+		between += float64(m.sizes[c]) * EuclideanDistanceSquared(m.centroids[c], mean)
+	}
+	if m.inertia == 0 {
+		return 1, nil
+	}
+	return between * float64(n-m.k) / (m.inertia * float64(m.k-1)), nil
+}
+
+// DunnIndex returns the ratio between the smallest distance between observations of different clusters
+// and the largest distance between observations of the same cluster, higher is better.
+// As the computation is quadratic, when sample is positive and less than the number of observations,
+// the index is estimated on sample evenly spaced observations.
+// It is 0 when undefined, with less than 2 clusters or no cluster of at least 2 observations.
+// It returns ErrNotFitted for models without training data, such as loaded ones.
+func (m *Model) DunnIndex(sample int) (float64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.requireData(); err != nil {
+		return 0, err
+	}
+	if m.k < 2 {
+		return 0, nil
+	}
+	step := 1
+	if sample > 0 && sample < len(m.data) {
+		step = len(m.data) / sample
+	}
+
+	separation, diameter := math.Inf(1), float64(0)
+	for i := 0; i < len(m.data); i += step {
+		for j := i + step; j < len(m.data); j += step {
+			d := m.distanceFn(m.data[i], m.data[j])
+			if m.mapping[i] == m.mapping[j] {
+				diameter = max(diameter, d)
+			} else {
+				separation = min(separation, d)
+			}
+		}
+	}
+	if diameter == 0 || math.IsInf(separation, 1) {
+		return 0, nil
+	}
+	return separation / diameter, nil
+}
+
+// requireData returns ErrNotFitted if the model has no training data, the caller must hold the lock.
+func (m *Model) requireData() error {
+	if len(m.data) == 0 || len(m.mapping) == 0 || m.sizes == nil {
+		return fmt.Errorf("%w: no training data", ErrNotFitted)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package kmeans
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDaviesBouldinIndex(t *testing.T) {
+	data := blobs(50)
+	good, err := fit(t, 3, data).DaviesBouldinIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad, err := NewTrainer(3, WithInitialCentroids(Dataset{{0, 0}, {0, 1}, {20, 10}}), WithMaxIterations(1)).Fit(data).DaviesBouldinIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(good < bad) {
+		t.Errorf("DaviesBouldinIndex() = %v for the true clusters, want less than %v", good, bad)
+	}
+}
+
+func TestDaviesBouldinIndexNotFitted(t *testing.T) {
+	m, err := NewFromCentroids(Dataset{{0}, {1}}, EuclideanDistance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.DaviesBouldinIndex(); !errors.Is(err, ErrNotFitted) {
+		t.Errorf("DaviesBouldinIndex() error = %v, want %v", err, ErrNotFitted)
+	}
+}
+
+func TestMetricsNotFitted(t *testing.T) {
+	m, err := NewFromCentroids(Dataset{{0}, {1}}, EuclideanDistance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics := map[string]func() (float64, error){
+		"SilhouetteScore":       func() (float64, error) { return m.SilhouetteScore(0) },
+		"CalinskiHarabaszScore": m.CalinskiHarabaszScore,
+		"DunnIndex":             func() (float64, error) { return m.DunnIndex(0) },
+		"DaviesBouldinIndex":    m.DaviesBouldinIndex,
+	}
+	for name, metric := range metrics {
+		if _, err := metric(); !errors.Is(err, ErrNotFitted) {
+			t.Errorf("%s() error = %v, want %v", name, err, ErrNotFitted)
+		}
+	}
+}
+
+func TestMetricsRankClusterings(t *testing.T) {
+	data := blobs(50)
+	good := fit(t, 3, data)
+	bad := NewTrainer(3, WithInitialCentroids(Dataset{{0, 0}, {0, 1}, {20, 10}}), WithMaxIterations(1)).Fit(data)
+	metrics := map[string]func(m *Model) (float64, error){
+		"SilhouetteScore":       func(m *Model) (float64, error) { return m.SilhouetteScore(0) },
+		"CalinskiHarabaszScore": (*Model).CalinskiHarabaszScore,
+		"DunnIndex":             func(m *Model) (float64, error) { return m.DunnIndex(0) },
+	}
+	for name, metric := range metrics {
+		g, err := metric(good)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := metric(bad)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !(g > b) {
+			t.Errorf("%s() = %v for the true clusters, want more than %v", name, g, b)
+		}
+	}
+}
+
+func TestDaviesBouldinIndexCoincidingCentroids(t *testing.T) {
+	m := &Model{
+		k:          3,
+		distanceFn: EuclideanDistance,
+		data:       Dataset{{0}, {2}, {1}, {9}, {11}},
+		centroids:  Dataset{{1}, {1}, {10}},
+		mapping:    []int{0, 0, 1, 2, 2},
+	}
+	m.summarize()
+	got, err := m.DaviesBouldinIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.IsNaN(got) || math.IsInf(got, 0) {
+		t.Errorf("DaviesBouldinIndex() = %v, want a finite value", got)
+	}
+}
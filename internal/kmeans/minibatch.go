@@ -0,0 +1,71 @@
+package kmeans
+
+import (
+	"context"
+	"math/rand"
+)
+
+// WithMiniBatch train using mini-batch kmeans: each iteration samples batchSize observations
+// and moves their centroids with a per-centroid learning rate (Sculley, 2010).
+// It converges much faster on large datasets in exchange of accuracy.
+// A final pass assigns every observation so Guesses remains complete.
+func WithMiniBatch(batchSize int) TrainerOption {
+	return func(t *Trainer) {
+		t.batchSize = batchSize
+	}
+}
+
+// miniBatch run the mini-batch iterations on an initialized model, returns the number of iterations.
+func (t Trainer) miniBatch(ctx context.Context, model *Model, rng *rand.Rand) (int, error) {
+	data := model.data
+	counts := make([]float64, t.k)
+	batch := make([]int, t.batchSize)
+	nearest := make([]int, t.batchSize)
+	_, previous := prepare(t.k, len(model.centroids[0]))
+
+	model.reason = ReasonMaxIterations
+	iter := 0
+	for ; iter < t.maxIterations; iter++ {
+		if err := ctx.Err(); err != nil {
+			model.reason = ReasonCancelled
+			return iter, err
+		}
+
+		for i := range previous {
+			copy(previous[i], model.centroids[i])
+		}
+		for b := range batch {
+			batch[b] = rng.Intn(len(data))
+			nearest[b], _ = model.predict(data[batch[b]])
+		}
+
+		for b, i := range batch {
+			c := model.centroids[nearest[b]]
+			w := model.weight(i)
+			if w == 0 {
+				continue
+			}
+			counts[nearest[b]] += w
+			eta := w / counts[nearest[b]]
+			for j := range c {
+				c[j] = (1-eta)*c[j] + eta*data[i][j]
+			}
+			if t.spherical {
+				normalize(c)
+			}
+		}
+
+		model.shift = model.centroidShift(previous)
+		if model.shift < t.tolerance {
+			model.reason = ReasonToleranceMet
+			iter++
+			break
+		}
+	}
+
+	cb, cn := prepare(t.k, len(model.centroids[0]))
+	buf := t.newAssignBuffers(len(data), len(model.centroids[0]))
+	defer buf.close()
+	t.assign(model, make([]float64, len(data)), cb, cn, buf)
+	return iter, nil
+}
@@ -1,39 +1,138 @@
 package kmeans
 
 import (
+	"context"
+	"fmt"
 	"gonum.org/v1/gonum/floats"
 	"math"
 	"math/rand"
 	"runtime"
+	"slices"
+	"sync"
+	"time"
 )
 
+// Dataset is a list of observations, each observation is a vector of the same dimension.
 type Dataset [][]float64
 
+// Trainer holds the kmeans configuration, create it with NewTrainer and train models with Fit.
 type Trainer struct {
 	k             int
 	maxIterations int
 	distanceFn    DistanceFunc
+	distanceName  string
 	delta         float64
 	concurrency   int
+	seed          int64
+	seeded        bool
+	spherical     bool
+	empty         EmptyClusterStrategy
+	batchSize     int
+	elkan         bool
+	hamerly       bool
+	update        UpdateMode
+	history       bool
+	dba           int
+	greedy        int
+	median        bool
+	tolerance     float64
+	restarts      int
+	initial       Dataset
+	schedule      func(step int) float64
+	init          InitMethod
+	canonical     bool
+	callback      func(iter int, inertia float64, changes int) bool
+	minSize       int
+	mustLink      [][2]int
+	cannotLink    [][2]int
+	// parallelRounds and oversample configure the kmeans|| initialization.
+	parallelRounds int
+	oversample     float64
 }
 
+// TrainerOption configure a Trainer, see the With* functions.
 type TrainerOption func(*Trainer)
 
+// ConvergenceReason tells why the training stopped.
+type ConvergenceReason int
+
+const (
+	// ReasonMaxIterations means the maximum number of iterations was reached.
+	ReasonMaxIterations ConvergenceReason = iota
+	// ReasonChangesStable means the number of changed assignments dropped below the delta threshold.
+	ReasonChangesStable
+	// ReasonToleranceMet means the centroids moved less than the tolerance.
+	ReasonToleranceMet
+	// ReasonCancelled means the context was done.
+	ReasonCancelled
+	// ReasonCallback means the iteration callback asked to stop.
+	ReasonCallback
+)
+
+func (r ConvergenceReason) String() string {
+	switch r {
+	case ReasonChangesStable:
+		return "ChangesStable"
+	case ReasonToleranceMet:
+		return "ToleranceMet"
+	case ReasonCancelled:
+		return "Cancelled"
+	case ReasonCallback:
+		return "Callback"
+	default:
+		return "MaxIterations"
+	}
+}
+
+// EmptyClusterStrategy specify how to handle a cluster that lost all its observations.
+type EmptyClusterStrategy int
+
+const (
+	// EmptyClusterFarthest move the centroid to the observation farthest from its centroid.
+	EmptyClusterFarthest EmptyClusterStrategy = iota
+	// EmptyClusterRandom move the centroid to a random observation.
+	EmptyClusterRandom
+	// EmptyClusterKeep keep the previous centroid.
+	EmptyClusterKeep
+)
+
+// Model is a trained kmeans model.
 type Model struct {
-	distanceFn DistanceFunc
-	k          int
-	data       Dataset
-	centroids  Dataset
-	mapping    []int
-	iter       int
+	// mu guards centroids and counts against concurrent online updates.
+	mu           sync.RWMutex
+	distanceFn   DistanceFunc
+	distanceName string
+	k            int
+	data         Dataset
+	centroids    Dataset
+	mapping      []int
+	weights      []float64
+	iter         int
+	inertia      float64
+	shift        float64
+	reason       ConvergenceReason
+	counts       []int
+	schedule     func(step int) float64
+	sizes        []int
+	history      []float64
+	// norms caches the squared norms of the observations for EuclideanDistance,
+	// and expand whether they can be used, see observationNorms.
+	norms  []float64
+	expand bool
+	// spreads caches the mean distance of each cluster observations to its centroid, for AnomalyScore.
+	spreads []float64
+	// trainer is the configuration the model was trained with, for ContinueLearn.
+	trainer *Trainer
 }
 
-// NewTrainer create new Trainer.
+// NewTrainer create new Trainer for k clusters configured by options.
+// By default, it uses EuclideanDistance, 100 maximum iterations, a delta threshold of 0.01 and runtime.NumCPU() goroutines.
 func NewTrainer(k int, options ...TrainerOption) Trainer {
 	t := Trainer{
 		k:             k,
 		maxIterations: 100,
 		distanceFn:    EuclideanDistance,
+		distanceName:  "EuclideanDistance",
 		delta:         0.01,
 		concurrency:   runtime.NumCPU(),
 	}
@@ -43,103 +142,699 @@ func NewTrainer(k int, options ...TrainerOption) Trainer {
 	return t
 }
 
+// WithDistanceFunc use fn to measure the distance between observations and centroids.
+// Built-in distances are recognized, so they keep enabling WithElkan and serialization like WithDistance.
 func WithDistanceFunc(fn DistanceFunc) TrainerOption {
 	return func(t *Trainer) {
 		t.distanceFn = fn
+		t.distanceName = distanceName(fn)
+	}
+}
+
+// WithDistance use the distance function registered under name.
+// Unlike WithDistanceFunc, models trained with a registered distance can be serialized.
+func WithDistance(name string) TrainerOption {
+	return func(t *Trainer) {
+		t.distanceFn, _ = LookupDistance(name)
+		t.distanceName = name
 	}
 }
 
+// WithMaxIterations set the maximum number of iterations before stopping.
 func WithMaxIterations(i int) TrainerOption {
 	return func(t *Trainer) {
 		t.maxIterations = i
 	}
 }
 
+// WithDeltaThreshold stop training when less than delta * len(data) observations changed cluster during an iteration.
 func WithDeltaThreshold(delta float64) TrainerOption {
 	return func(t *Trainer) {
 		t.delta = delta
 	}
 }
 
+// WithConcurrency set the number of goroutines used to assign observations to clusters,
+// default to runtime.NumCPU().
 func WithConcurrency(c int) TrainerOption {
 	return func(t *Trainer) {
 		t.concurrency = c
 	}
 }
 
+// WithSeed make the training reproducible by using a random source seeded with seed.
+// Without it, each Fit draw a new seed from the current time.
+func WithSeed(seed int64) TrainerOption {
+	return func(t *Trainer) {
+		t.seed = seed
+		t.seeded = true
+	}
+}
+
+// WithSpherical enable spherical kmeans: observations are L2-normalized before training
+// and centroids are re-normalized after each update, usually paired with CosineDistance.
+// Zero vectors are left as is.
+func WithSpherical() TrainerOption {
+	return func(t *Trainer) {
+		t.spherical = true
+	}
+}
+
+// WithEmptyClusterStrategy specify how to handle empty clusters, default to EmptyClusterFarthest.
+func WithEmptyClusterStrategy(strategy EmptyClusterStrategy) TrainerOption {
+	return func(t *Trainer) {
+		t.empty = strategy
+	}
+}
+
+// WithTolerance stop training when no centroid moved more than tol during an iteration,
+// in addition to the delta threshold. Disabled by default.
+func WithTolerance(tol float64) TrainerOption {
+	return func(t *Trainer) {
+		t.tolerance = tol
+	}
+}
+
+// WithRestarts run the whole training n times from different initializations and keep the model with the lowest inertia.
+// With WithSeed, restarts draw their initializations from the same seeded source, so the result stays reproducible.
+func WithRestarts(n int) TrainerOption {
+	return func(t *Trainer) {
+		t.restarts = n
+	}
+}
+
+// WithInitialCentroids start training from the given centroids instead of the kmeans++ initialization,
+// for example to warm start from a previous model Centroids.
+// The number of centroids must equal k and their dimension must match the data.
+func WithInitialCentroids(centroids Dataset) TrainerOption {
+	c := make(Dataset, len(centroids))
+	for i := range centroids {
+		c[i] = slices.Clone(centroids[i])
+	}
+	return func(t *Trainer) {
+		t.initial = c
+	}
+}
+
 // Fit create and train the *Model.
+// It panics if the dataset or the trainer configuration is invalid, use TryFit to get an error instead.
 func (t Trainer) Fit(data Dataset) *Model {
-	model := Model{data: data, k: t.k, distanceFn: t.distanceFn}
-	model.initializeMean()
+	m, err := t.TryFit(data)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryFit create and train the *Model, returning an error if the dataset or the trainer configuration is invalid.
+func (t Trainer) TryFit(data Dataset) (*Model, error) {
+	return t.FitContext(context.Background(), data)
+}
+
+// FitContext create and train the *Model, stopping early when ctx is done.
+// On cancellation, the partially trained *Model is returned along with ctx.Err().
+func (t Trainer) FitContext(ctx context.Context, data Dataset) (*Model, error) {
+	if err := t.validate(data); err != nil {
+		return nil, err
+	}
+	return t.fit(ctx, data, nil)
+}
+
+// FitPredict train a model and returns the cluster number of each observation, like Guesses.
+func (t Trainer) FitPredict(data Dataset) ([]int, error) {
+	m, err := t.TryFit(data)
+	if err != nil {
+		return nil, err
+	}
+	return m.Guesses(), nil
+}
+
+// FitWeighted create and train the *Model where each observation pull its centroid proportionally to its weight,
+// for example when an observation represents an aggregated count.
+func (t Trainer) FitWeighted(data Dataset, weights []float64) (*Model, error) {
+	return t.FitWeightedContext(context.Background(), data, weights)
+}
+
+// FitWeightedContext is FitWeighted stopping early when ctx is done.
+func (t Trainer) FitWeightedContext(ctx context.Context, data Dataset, weights []float64) (*Model, error) {
+	if err := t.validate(data); err != nil {
+		return nil, err
+	}
+	if err := validateWeights(data, weights); err != nil {
+		return nil, err
+	}
+	return t.fit(ctx, data, weights)
+}
+
+func (t Trainer) validate(data Dataset) error {
+	if t.distanceFn == nil {
+		return fmt.Errorf("%w: %q", ErrUnknownDistance, t.distanceName)
+	}
+	if t.maxIterations < 1 {
+		return fmt.Errorf("%w: got %d", ErrZeroIterations, t.maxIterations)
+	}
+	if t.k < 1 {
+		return fmt.Errorf("%w: got %d clusters", ErrInvalidClusters, t.k)
+	}
+	if len(data) == 0 {
+		return ErrEmptyDataset
+	}
+	if t.k > len(data) {
+		return fmt.Errorf("%w: %d clusters requested for %d observations", ErrInvalidClusters, t.k, len(data))
+	}
+	if len(data[0]) == 0 {
+		return fmt.Errorf("%w: observations must have at least one dimension", ErrDimensionMismatch)
+	}
+	if t.distanceName == "HaversineDistance" && len(data[0]) < 2 {
+		return fmt.Errorf("%w: HaversineDistance requires latitude and longitude, got %d dimension", ErrDimensionMismatch, len(data[0]))
+	}
+	for i := range data {
+		if len(data[i]) != len(data[0]) {
+			return fmt.Errorf("%w: observation %d has %d dimensions, expected %d", ErrDimensionMismatch, i, len(data[i]), len(data[0]))
+		}
+		if j := nonFinite(data[i]); j >= 0 {
+			return fmt.Errorf("%w: observation %d has %v at dimension %d", ErrNonFinite, i, data[i][j], j)
+		}
+	}
+	if t.batchSize > 0 && (t.mustLink != nil || t.cannotLink != nil) {
+		return fmt.Errorf("%w: WithMiniBatch does not support WithConstraints", ErrIncompatibleOptions)
+	}
+	if t.batchSize > 0 && t.minSize > 0 {
+		return fmt.Errorf("%w: WithMiniBatch does not support WithMinClusterSize", ErrIncompatibleOptions)
+	}
+	if t.update == MacQueen && t.batchSize == 0 {
+		switch {
+		case t.median:
+			return fmt.Errorf("%w: MacQueen updates do not support WithMedianUpdate", ErrIncompatibleOptions)
+		case t.minSize > 0:
+			return fmt.Errorf("%w: MacQueen updates do not support WithMinClusterSize", ErrIncompatibleOptions)
+		case t.mustLink != nil || t.cannotLink != nil:
+			return fmt.Errorf("%w: MacQueen updates do not support WithConstraints", ErrIncompatibleOptions)
+		}
+	}
+	if t.minSize*t.k > len(data) {
+		return fmt.Errorf("%w: %d clusters of at least %d observations requested for %d observations", ErrInvalidClusters, t.k, t.minSize, len(data))
+	}
+	if t.mustLink != nil || t.cannotLink != nil {
+		if _, err := newConstraints(len(data), t.mustLink, t.cannotLink); err != nil {
+			return err
+		}
+	}
+	if t.initial != nil {
+		if len(t.initial) != t.k {
+			return fmt.Errorf("%w: %d initial centroids for %d clusters", ErrInvalidClusters, len(t.initial), t.k)
+		}
+		for i := range t.initial {
+			if len(t.initial[i]) != len(data[0]) {
+				return fmt.Errorf("%w: initial centroid %d has %d dimensions, expected %d", ErrDimensionMismatch, i, len(t.initial[i]), len(data[0]))
+			}
+			if j := nonFinite(t.initial[i]); j >= 0 {
+				return fmt.Errorf("%w: initial centroid %d has %v at dimension %d", ErrNonFinite, i, t.initial[i][j], j)
+			}
+		}
+	}
+	return nil
+}
+
+// nonFinite returns the index of the first NaN or infinite value of p, or -1.
+func nonFinite(p []float64) int {
+	for j, v := range p {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return j
+		}
+	}
+	return -1
+}
+
+func validateWeights(data Dataset, weights []float64) error {
+	if len(weights) != len(data) {
+		return fmt.Errorf("%w: got %d weights for %d observations", ErrInvalidWeights, len(weights), len(data))
+	}
+	s := float64(0)
+	for i, w := range weights {
+		if w < 0 || math.IsInf(w, 0) || math.IsNaN(w) {
+			return fmt.Errorf("%w: weight %d is %v", ErrInvalidWeights, i, w)
+		}
+		s += w
+	}
+	if s == 0 {
+		return fmt.Errorf("%w: all weights are zero", ErrInvalidWeights)
+	}
+	return nil
+}
+
+func (t Trainer) fit(ctx context.Context, data Dataset, weights []float64) (*Model, error) {
+	if t.spherical {
+		data = normalized(data)
+	}
+	rng := t.newRand()
+
+	var (
+		best *Model
+		err  error
+	)
+	for range max(t.restarts, 1) {
+		var model *Model
+		model, err = t.run(ctx, data, weights, rng)
+		if best == nil || model.inertia < best.inertia {
+			best = model
+		}
+		if err != nil {
+			break
+		}
+	}
+	if t.canonical {
+		best.canonicalize()
+	}
+	return best, err
+}
+
+// run train a single model from a new initialization.
+func (t Trainer) run(ctx context.Context, data Dataset, weights []float64, rng *rand.Rand) (*Model, error) {
+	model := Model{data: data, weights: weights, k: t.k, distanceFn: t.distanceFn, distanceName: t.distanceName, schedule: t.schedule, trainer: &t}
+	if t.initial != nil {
+		model.mapping = make([]int, len(data))
+		model.centroids = make(Dataset, t.k)
+		for i := range t.initial {
+			model.centroids[i] = slices.Clone(t.initial[i])
+			if t.spherical {
+				normalize(model.centroids[i])
+			}
+		}
+	} else {
+		t.initialize(ctx, &model, rng)
+	}
+
+	var (
+		iter int
+		err  error
+	)
+	if t.batchSize > 0 {
+		iter, err = t.miniBatch(ctx, &model, rng)
+	} else if t.update == MacQueen {
+		iter, err = t.macQueen(ctx, &model)
+	} else {
+		iter, err = t.lloyd(ctx, &model, rng)
+	}
+	model.iter = iter
+	model.summarize()
+	return &model, err
+}
+
+// lloyd run the batch kmeans iterations on an initialized model, returns the number of iterations run.
+func (t Trainer) lloyd(ctx context.Context, model *Model, rng *rand.Rand) (int, error) {
 	l := len(model.centroids[0])
-	changeThreshold := int(float64(len(data)) * t.delta)
+	changeThreshold := int(float64(len(model.data)) * t.delta)
+	dist := make([]float64, len(model.data))
+
+	var cons *constraints
+	if t.mustLink != nil || t.cannotLink != nil {
+		var err error
+		if cons, err = newConstraints(len(model.data), t.mustLink, t.cannotLink); err != nil {
+			return 0, err
+		}
+	}
+	var b bounds
+	if (t.elkan || t.hamerly) && isMetric(t.distanceName) && t.minSize == 0 && cons == nil {
+		if t.elkan {
+			b = newElkan(len(model.data), t.k)
+		} else {
+			b = newHamerly(len(model.data), t.k)
+		}
+	}
+	var assigned []int
+	if t.minSize > 0 && cons == nil {
+		assigned = make([]int, len(model.data))
+	}
 
 	cb, cn := prepare(t.k, l)
+	_, previous := prepare(t.k, l)
+	buf := t.newAssignBuffers(len(model.data), l)
+	defer buf.close()
+	model.reason = ReasonMaxIterations
 	iter := 0
 	for ; iter < t.maxIterations; iter++ {
-		changes := 0
-		icb := make([][]int, t.concurrency)
-		icn := make([]Dataset, t.concurrency)
-		ch := make(chan int, t.concurrency)
-		for num := range t.concurrency {
-			go func() {
-				defer func() {
-					ch <- num
-				}()
-				cb, cn := prepare(t.k, l)
-				for i := num; i < len(data); i += t.concurrency {
-					m := t.distanceFn(data[i], model.centroids[0])
-					n := 0
-
-					for j := 1; j < t.k; j++ {
-						if d := t.distanceFn(data[i], model.centroids[j]); d < m {
-							m = d
-							n = j
-						}
-					}
-
-					if model.mapping[i] != n {
-						changes++
-					}
-
-					model.mapping[i] = n
-					cb[n]++
-					floats.Add(cn[n], data[i])
-				}
-				icb[num] = cb
-				icn[num] = cn
-			}()
+		if err := ctx.Err(); err != nil {
+			model.reason = ReasonCancelled
+			return iter, err
 		}
-
-		for range t.concurrency {
-			num := <-ch
-			for n := range t.k {
-				cb[n] += icb[num][n]
-				floats.Add(cn[n], icn[num][n])
+		for i := range previous {
+			copy(previous[i], model.centroids[i])
+		}
+		if assigned != nil {
+			copy(assigned, model.mapping)
+		}
+		var changes int
+		if cons != nil {
+			var err error
+			if changes, err = t.constrainedAssign(model, dist, cb, cn, cons); err != nil {
+				return iter, err
 			}
+		} else if b != nil {
+			changes = b.assign(t, model, dist, cb, cn, buf)
+		} else {
+			changes = t.assign(model, dist, cb, cn, buf)
+		}
+		if assigned != nil {
+			changes = t.balance(model, dist, cb, cn, assigned)
+		}
+
+		if t.median {
+			updateMedians(model)
+		} else if t.dba > 0 {
+			t.updateDBA(model)
 		}
 
+		reinitialized := false
 		for i := 0; i < t.k; i++ {
-			floats.Scale(1/float64(cb[i]), cn[i])
+			if cb[i] == 0 {
+				reinitialized = t.reinitialize(model, i, dist, rng) || reinitialized
+				continue
+			}
+			floats.Scale(1/cb[i], cn[i])
 			cb[i] = 0
 
 			for j := 0; j < l; j++ {
-				model.centroids[i][j] = cn[i][j]
+				if !t.median && t.dba == 0 {
+					model.centroids[i][j] = cn[i][j]
+				}
 				cn[i][j] = 0
 			}
+			if t.spherical {
+				normalize(model.centroids[i])
+			}
 		}
 
-		if changes < changeThreshold {
-			break
+		if b != nil {
+			b.move(t, model)
+		}
+
+		model.shift = model.centroidShift(previous)
+		if !t.observe(model, iter, changes) {
+			model.reason = ReasonCallback
+			return iter + 1, nil
+		}
+		if reinitialized {
+			continue
+		}
+		// A zero threshold (small dataset or delta) still stops once memberships are stable.
+		if changes == 0 || changes < changeThreshold {
+			model.reason = ReasonChangesStable
+			return iter + 1, nil
+		}
+		if model.shift < t.tolerance {
+			model.reason = ReasonToleranceMet
+			return iter + 1, nil
 		}
 	}
+	return iter, nil
+}
 
-	model.iter = iter
-	return &model
+// assign map each observation to its nearest centroid, storing the distance (possibly squared, see compareFn) in dist,
+// and accumulate the cluster weights and weighted sums into cb and cn. Returns the number of changed assignments.
+// It does not allocate, the work is run by the goroutines of buf.
+func (t Trainer) assign(model *Model, dist []float64, cb []float64, cn Dataset, buf *assignBuffers) int {
+	distanceFn, squared := compareFn(t.distanceFn)
+	buf.step = assignStep{model: model, dist: dist, distanceFn: distanceFn}
+	if norms, ok := model.observationNorms(); squared && ok {
+		// ||a-b||² = ||a||² + ||b||² - 2a·b, so only a dot product is needed per pair.
+		buf.step.norms, buf.step.cnorms = norms, squaredNormsInto(buf.norms, model.centroids)
+	}
+	buf.run(len(model.data))
+	buf.step = assignStep{}
+	return reduce(buf.cb, buf.cn, buf.changes, cb, cn)
+}
+
+// assignStep is the state of the assignment run by assignBuffers.
+type assignStep struct {
+	model      *Model
+	dist       []float64
+	distanceFn DistanceFunc
+	// norms and cnorms are the squared norms of the observations and centroids, nil unless using the norms expansion.
+	norms, cnorms []float64
+}
+
+// nearest returns the nearest centroid of observation i and the distance to it.
+func (s *assignStep) nearest(i int) (int, float64) {
+	p, centroids := s.model.data[i], s.model.centroids
+	if s.norms != nil {
+		m := math.Inf(1)
+		n := 0
+		for j := range centroids {
+			if d := s.norms[i] + s.cnorms[j] - 2*floats.Dot(p, centroids[j]); d < m {
+				m = d
+				n = j
+			}
+		}
+		return n, max(m, 0)
+	}
+	m := s.distanceFn(p, centroids[0])
+	n := 0
+	for j := 1; j < len(centroids); j++ {
+		if d := s.distanceFn(p, centroids[j]); d < m {
+			m = d
+			n = j
+		}
+	}
+	return n, m
+}
+
+// assignBuffers holds the per-worker accumulators of assign and the goroutines running it,
+// so they are allocated once per training. It must be closed once the training is done.
+type assignBuffers struct {
+	cb      [][]float64
+	cn      []Dataset
+	changes []int
+	norms   []float64
+	step    assignStep
+	// jobs sends the chunk of observations to assign to each worker, started on the first run.
+	jobs []chan [2]int
+	wg   sync.WaitGroup
+}
+
+func (t Trainer) newAssignBuffers(n, l int) *assignBuffers {
+	workers := t.workers(n)
+	buf := &assignBuffers{cb: make([][]float64, workers), cn: make([]Dataset, workers), changes: make([]int, workers), norms: make([]float64, t.k)}
+	for num := range workers {
+		buf.cb[num], buf.cn[num] = prepare(t.k, l)
+	}
+	return buf
+}
+
+// run assign the n observations of the current step, split into a contiguous chunk per worker.
+func (b *assignBuffers) run(n int) {
+	workers := len(b.changes)
+	if workers == 1 {
+		b.chunk(0, 0, n)
+		return
+	}
+	if b.jobs == nil {
+		b.jobs = make([]chan [2]int, workers)
+		for num := range b.jobs {
+			b.jobs[num] = make(chan [2]int)
+			go b.work(num)
+		}
+	}
+	chunk := (n + workers - 1) / workers
+	b.wg.Add(workers)
+	for num := range b.jobs {
+		b.jobs[num] <- [2]int{min(num*chunk, n), min((num+1)*chunk, n)}
+	}
+	b.wg.Wait()
+}
+
+func (b *assignBuffers) work(num int) {
+	for span := range b.jobs[num] {
+		b.chunk(num, span[0], span[1])
+		b.wg.Done()
+	}
+}
+
+// chunk assign the observations in [from, to) using the accumulators of worker num.
+func (b *assignBuffers) chunk(num, from, to int) {
+	s := &b.step
+	cb, cn := b.reset(num)
+	changes := 0
+	for i := from; i < to; i++ {
+		n, m := s.nearest(i)
+
+		if s.model.mapping[i] != n {
+			changes++
+		}
+
+		s.model.mapping[i] = n
+		s.dist[i] = m
+		w := s.model.weight(i)
+		cb[n] += w
+		floats.AddScaled(cn[n], w, s.model.data[i])
+	}
+	b.changes[num] = changes
+}
+
+// close stop the workers.
+func (b *assignBuffers) close() {
+	for _, jobs := range b.jobs {
+		close(jobs)
+	}
+	b.jobs = nil
+}
+
+// reset zero and returns the accumulators of worker num.
+func (b *assignBuffers) reset(num int) ([]float64, Dataset) {
+	clear(b.cb[num])
+	for i := range b.cn[num] {
+		clear(b.cn[num][i])
+	}
+	return b.cb[num], b.cn[num]
+}
+
+// normsMaxRatio is the largest ratio between the squared norm of an observation and the variance of the data
+// for which the ||a||²+||b||²-2a·b expansion is used: the cancellation loses about log10(ratio) significant digits
+// on distances of the order of the data spread, so data far from the origin use the direct difference.
+const normsMaxRatio = 1e6
+
+// observationNorms returns the squared L2 norms of the observations, computed once per model,
+// and whether they are small enough relative to the data variance for the norms expansion to be accurate.
+func (m *Model) observationNorms() ([]float64, bool) {
+	if m.norms == nil {
+		m.norms = squaredNorms(m.data)
+		m.expand = floats.Max(m.norms) <= normsMaxRatio*variance(m.data)
+	}
+	return m.norms, m.expand
+}
+
+// variance returns the mean squared euclidean distance between the observations and their mean.
+func variance(data Dataset) float64 {
+	mean := make([]float64, len(data[0]))
+	for i := range data {
+		floats.Add(mean, data[i])
+	}
+	floats.Scale(1/float64(len(data)), mean)
+	s := float64(0)
+	for i := range data {
+		s += EuclideanDistanceSquared(data[i], mean)
+	}
+	return s / float64(len(data))
+}
+
+func squaredNorms(data Dataset) []float64 {
+	return squaredNormsInto(make([]float64, len(data)), data)
+}
+
+func squaredNormsInto(norms []float64, data Dataset) []float64 {
+	for i := range data {
+		norms[i] = floats.Dot(data[i], data[i])
+	}
+	return norms
+}
+
+// reduce accumulate the per-worker weights and sums into cb and cn, returns the total of changes.
+func reduce(icb [][]float64, icn []Dataset, ichanges []int, cb []float64, cn Dataset) int {
+	changes := 0
+	for num := range icb {
+		changes += ichanges[num]
+		for n := range cb {
+			cb[n] += icb[num][n]
+			floats.Add(cn[n], icn[num][n])
+		}
+	}
+	return changes
+}
+
+// workers returns the number of goroutines used to process n observations.
+func (t Trainer) workers(n int) int {
+	return max(min(t.concurrency, n), 1)
+}
+
+// runChunks split [0, n) into workers contiguous chunks and run fn on each of them concurrently.
+func runChunks(workers, n int, fn func(num, from, to int)) {
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for num := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(num, min(num*chunk, n), min((num+1)*chunk, n))
+		}()
+	}
+	wg.Wait()
+}
+
+// reinitialize handle the empty cluster i according to the empty cluster strategy,
+// returns whether its centroid was moved to an observation not already lying on a centroid.
+func (t Trainer) reinitialize(m *Model, i int, dist []float64, rng *rand.Rand) bool {
+	p := 0
+	switch t.empty {
+	case EmptyClusterKeep:
+		return false
+	case EmptyClusterRandom:
+		p = rng.Intn(len(m.data))
+	default:
+		for j := range dist {
+			if dist[j] > dist[p] {
+				p = j
+			}
+		}
+	}
+	moved := dist[p] > 0
+	// The observation now has a centroid on itself, avoid picking it for another empty cluster.
+	dist[p] = 0
+	m.centroids[i] = append([]float64(nil), m.data[p]...)
+	return moved
+}
+
+// centroidShift returns the maximum distance between the previous and the current centroids.
+func (m *Model) centroidShift(previous Dataset) float64 {
+	s := float64(0)
+	for i := range previous {
+		s = max(s, m.distanceFn(previous[i], m.centroids[i]))
+	}
+	return s
+}
+
+// weight returns the weight of observation i.
+func (m *Model) weight(i int) float64 {
+	if m.weights == nil {
+		return 1
+	}
+	return m.weights[i]
+}
+
+// summarize caches the statistics of the fitted model.
+func (m *Model) summarize() {
+	m.sizes = make([]int, m.k)
+	for i := range m.data {
+		m.sizes[m.mapping[i]]++
+	}
+	m.inertia = m.computeInertia()
+	m.spreads = m.spread()
+}
+
+func (t Trainer) newRand() *rand.Rand {
+	if t.seeded {
+		return rand.New(rand.NewSource(t.seed))
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
 }
 
-func prepare(k int, l int) ([]int, Dataset) {
-	cb := make([]int, k)
+// normalized returns a L2-normalized copy of data.
+func normalized(data Dataset) Dataset {
+	n := make(Dataset, len(data))
+	for i := range data {
+		n[i] = append([]float64(nil), data[i]...)
+		normalize(n[i])
+	}
+	return n
+}
+
+func normalize(v []float64) {
+	if n := floats.Norm(v, 2); n > 0 {
+		floats.Scale(1/n, v)
+	}
+}
+
+func prepare(k int, l int) ([]float64, Dataset) {
+	cb := make([]float64, k)
 	cn := make(Dataset, k)
 	for i := 0; i < k; i++ {
 		cn[i] = make([]float64, l)
@@ -147,60 +842,311 @@ func prepare(k int, l int) ([]int, Dataset) {
 	return cb, cn
 }
 
-func (m *Model) initializeMean() {
+// initializeFrom use the observations at seeds as initial centroids.
+func (m *Model) initializeFrom(seeds []int) {
 	m.mapping = make([]int, len(m.data))
 	m.centroids = make(Dataset, m.k)
-	m.centroids[0] = m.data[rand.Intn(len(m.data)-1)]
+	for i, p := range seeds {
+		// Copy the observation, as centroids are updated in place.
+		m.centroids[i] = append([]float64(nil), m.data[p]...)
+	}
+}
+
+// seed returns the distinct indices of the observations picked as initial centroids by kmeans++.
+func (m *Model) seed(rng *rand.Rand) []int {
+	return m.seedGreedy(rng, 1)
+}
+
+// seedGreedy returns the distinct indices of the observations picked as initial centroids by greedy kmeans++:
+// candidates observations are sampled for each centroid, keeping the one reducing the potential the most.
+func (m *Model) seedGreedy(rng *rand.Rand, candidates int) []int {
+	distanceFn, squared := compareFn(m.distanceFn)
+	// potential returns the weighted squared distance between observations i and j.
+	potential := func(i, j int) float64 {
+		l := distanceFn(m.data[i], m.data[j])
+		if !squared {
+			l *= l
+		}
+		return l * m.weight(j)
+	}
+
+	seeds := make([]int, m.k)
+	seeds[0] = rng.Intn(len(m.data))
+	picked := make([]bool, len(m.data))
+	picked[seeds[0]] = true
 
+	// d is the potential of each observation to its nearest seed, 0 for seeds.
 	d := make([]float64, len(m.data))
+	for j := range d {
+		if !picked[j] {
+			d[j] = potential(seeds[0], j)
+		}
+	}
+	next := make([]float64, len(m.data))
+	best := make([]float64, len(m.data))
 	for i := 1; i < m.k; i++ {
-		s := float64(0)
-		for j := 0; j < len(m.data); j++ {
-			l := m.distanceFn(m.centroids[0], m.data[j])
-			for g := 1; g < i; g++ {
-				if f := m.distanceFn(m.centroids[g], m.data[j]); f < l {
-					l = f
+		s := floats.Sum(d)
+		if s == 0 {
+			// Every observation lies on a centroid, fallback to an uniform pick among the remaining ones.
+			k := rng.Intn(len(m.data) - i)
+			for j := range picked {
+				if picked[j] {
+					continue
 				}
+				if k == 0 {
+					seeds[i] = j
+					break
+				}
+				k--
 			}
-
-			d[j] = math.Pow(l, 2)
-			s += d[j]
+			picked[seeds[i]] = true
+			continue
 		}
 
-		t := rand.Float64() * s
-		k := 0
-		for s = d[0]; s < t; s += d[k] {
-			k++
+		lowest := math.Inf(1)
+		for range max(candidates, 1) {
+			t := rng.Float64() * s
+			k := 0
+			for acc := d[0]; acc < t && k < len(d)-1; acc += d[k] {
+				k++
+			}
+			// Rounding may land on an observation without weight, take the nearest candidate instead.
+			for d[k] == 0 && k > 0 {
+				k--
+			}
+			for d[k] == 0 {
+				k++
+			}
+
+			total := float64(0)
+			for j := range d {
+				next[j] = d[j]
+				if j == k {
+					next[j] = 0
+				} else if d[j] > 0 {
+					next[j] = min(d[j], potential(k, j))
+				}
+				total += next[j]
+			}
+			if total < lowest {
+				lowest = total
+				seeds[i] = k
+				best, next = next, best
+			}
 		}
+		picked[seeds[i]] = true
+		d, best = best, d
+	}
+	return seeds
+}
 
-		m.centroids[i] = m.data[k]
+// NewFromCentroids create a *Model from previously trained centroids, which can only be used for prediction.
+func NewFromCentroids(centroids Dataset, distance DistanceFunc) (*Model, error) {
+	if len(centroids) == 0 {
+		return nil, fmt.Errorf("%w: no centroid", ErrInvalidModel)
 	}
+	if distance == nil {
+		return nil, fmt.Errorf("%w: nil distance function", ErrInvalidModel)
+	}
+	if err := validateCentroids(centroids, len(centroids[0])); err != nil {
+		return nil, err
+	}
+
+	c := make(Dataset, len(centroids))
+	for i := range centroids {
+		c[i] = append([]float64(nil), centroids[i]...)
+	}
+	return &Model{k: len(c), distanceFn: distance, distanceName: distanceName(distance), centroids: c}, nil
 }
 
-// Predict returns number of cluster to which the observation would be assigned.
+func validateCentroids(centroids Dataset, dimension int) error {
+	if dimension < 1 {
+		return fmt.Errorf("%w: centroids must have at least one dimension", ErrInvalidModel)
+	}
+	for i := range centroids {
+		if len(centroids[i]) != dimension {
+			return fmt.Errorf("%w: centroid %d has %d dimensions, expected %d", ErrInvalidModel, i, len(centroids[i]), dimension)
+		}
+	}
+	return nil
+}
+
+// Predict returns number of cluster to which the observation would be assigned, in [0, k).
+// It panics with ErrNotFitted if the model has not been trained,
+// with ErrDimensionMismatch if the observation and the centroids dimensions differ,
+// or with ErrNonFinite if the observation contains a NaN or infinite value.
 func (m *Model) Predict(p []float64) int {
+	l, _ := m.PredictWithDistance(p)
+	return l
+}
+
+// PredictWithDistance returns number of cluster to which the observation would be assigned
+// and the distance between the observation and that cluster centroid.
+func (m *Model) PredictWithDistance(p []float64) (int, float64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.predict(p)
+}
+
+// predict is PredictWithDistance without locking.
+func (m *Model) predict(p []float64) (int, float64) {
+	if !m.fitted() {
+		panic(ErrNotFitted)
+	}
+	if len(p) != len(m.centroids[0]) {
+		panic(fmt.Errorf("%w: observation has %d dimensions, expected %d", ErrDimensionMismatch, len(p), len(m.centroids[0])))
+	}
+	if j := nonFinite(p); j >= 0 {
+		panic(fmt.Errorf("%w: observation has %v at dimension %d", ErrNonFinite, p[j], j))
+	}
+	distanceFn, squared := compareFn(m.distanceFn)
 	l := 0
-	n := m.distanceFn(p, m.centroids[0])
+	n := distanceFn(p, m.centroids[0])
 	for i := 1; i < m.k; i++ {
-		if d := m.distanceFn(p, m.centroids[i]); d < n {
+		if d := distanceFn(p, m.centroids[i]); d < n {
 			n = d
 			l = i
 		}
 	}
-	return l
+	if squared {
+		n = math.Sqrt(n)
+	}
+	return l, n
+}
+
+// IsFitted returns whether the model has centroids, either from training or loaded.
+func (m *Model) IsFitted() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.fitted()
 }
 
-// Guesses returns mapping from data point indices to cluster numbers.
+// fitted is IsFitted without locking.
+func (m *Model) fitted() bool {
+	return len(m.centroids) > 0
+}
+
+// Guesses returns a copy of the mapping from data point indices to cluster numbers, in [0, k) like Predict.
 func (m *Model) Guesses() []int {
-	return m.mapping
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.mapping)
+}
+
+// Sizes returns a copy of the number of observations assigned to each cluster.
+func (m *Model) Sizes() []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.sizes)
 }
 
-// Cluster returns cluster at position i.
+// Cluster returns a copy of the centroid of cluster i, using the same numbering as Predict and Guesses.
+// It panics with ErrNotFitted if the model has no centroids.
 func (m *Model) Cluster(i int) []float64 {
-	return m.centroids[i]
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.fitted() {
+		panic(ErrNotFitted)
+	}
+	return slices.Clone(m.centroids[i])
 }
 
-// Iter returns model number of iterations.
+// Centroids returns a copy of all clusters centroids, row i is the centroid of cluster i as numbered by Predict.
+// It is safe to call while Online is running.
+func (m *Model) Centroids() Dataset {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c := make(Dataset, len(m.centroids))
+	for i := range m.centroids {
+		c[i] = slices.Clone(m.centroids[i])
+	}
+	return c
+}
+
+// Shift returns the maximum distance moved by a centroid during the last iteration.
+func (m *Model) Shift() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.shift
+}
+
+// Iter returns model number of iterations run.
 func (m *Model) Iter() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.iter
 }
+
+// ConvergedReason returns why the training stopped.
+func (m *Model) ConvergedReason() ConvergenceReason {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.reason
+}
+
+// Reset clear the fitted state of the model: centroids, assignments, weights, sizes, online counts and convergence statistics,
+// releasing its reference to the training data. The distance, the number of clusters and the online schedule are kept.
+// A Trainer holds the configuration and can be reused to fit each dataset, Reset is meant to drop a model that is no longer needed.
+// A reset model cannot be trained again, only decoded into: methods returning an error return ErrNotFitted,
+// the others return zero values, or panic with ErrNotFitted like Predict.
+func (m *Model) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data = nil
+	m.centroids = nil
+	m.mapping = nil
+	m.weights = nil
+	m.norms = nil
+	m.sizes = nil
+	m.spreads = nil
+	m.trainer = nil
+	m.history = nil
+	m.counts = nil
+	m.iter = 0
+	m.inertia = 0
+	m.shift = 0
+	m.reason = ReasonMaxIterations
+}
+
+// Clone returns an independent copy of the model: centroids, assignments, sizes and online counts are copied,
+// the training data is shared as it is never modified.
+// It is safe to call while Online is running.
+func (m *Model) Clone() *Model {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c := &Model{
+		distanceFn:   m.distanceFn,
+		distanceName: m.distanceName,
+		k:            m.k,
+		data:         m.data,
+		centroids:    make(Dataset, len(m.centroids)),
+		mapping:      slices.Clone(m.mapping),
+		weights:      slices.Clone(m.weights),
+		iter:         m.iter,
+		inertia:      m.inertia,
+		shift:        m.shift,
+		reason:       m.reason,
+		counts:       slices.Clone(m.counts),
+		schedule:     m.schedule,
+		sizes:        slices.Clone(m.sizes),
+		history:      slices.Clone(m.history),
+		norms:        m.norms,
+		expand:       m.expand,
+		spreads:      slices.Clone(m.spreads),
+		trainer:      m.trainer,
+	}
+	for i := range m.centroids {
+		c.centroids[i] = slices.Clone(m.centroids[i])
+	}
+	return c
+}
+
+// String returns a summary of the model: clusters, iterations, convergence reason, inertia and cluster sizes.
+func (m *Model) String() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.fitted() {
+		return "kmeans: not fitted"
+	}
+	return fmt.Sprintf("kmeans: k=%d iter=%d stop=%s inertia=%g sizes=%v", m.k, m.iter, m.reason, m.inertia, m.sizes)
+}
@@ -0,0 +1,291 @@
+package kmeans
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// blobs returns n observations around each of the centers (0, 0), (10, 5) and (20, 10), in that order.
+func blobs(n int) Dataset {
+	r := rand.New(rand.NewSource(1))
+	data := make(Dataset, 0, 3*n)
+	for c := range 3 {
+		for range n {
+			data = append(data, []float64{float64(c*10) + r.NormFloat64(), float64(c*5) + r.NormFloat64()})
+		}
+	}
+	return data
+}
+
+// fit trains a model with a fixed seed, failing the test on error.
+func fit(t testing.TB, k int, data Dataset, options ...TrainerOption) *Model {
+	t.Helper()
+	m, err := NewTrainer(k, append([]TrainerOption{WithSeed(1)}, options...)...).TryFit(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestEmptyClusterStrategy(t *testing.T) {
+	data := Dataset{{0}, {0}, {0}, {10}}
+	// Every observation is nearer to the first initial centroid, leaving the others empty.
+	initial := WithInitialCentroids(Dataset{{0}, {100}, {200}})
+	tests := []struct {
+		strategy EmptyClusterStrategy
+		check    func(t *testing.T, m *Model)
+	}{
+		{EmptyClusterFarthest, func(t *testing.T, m *Model) {
+			if c := m.Predict([]float64{10}); m.Cluster(c)[0] != 10 {
+				t.Errorf("farthest observation centroid = %v, want [10]", m.Cluster(c))
+			}
+		}},
+		{EmptyClusterRandom, func(t *testing.T, m *Model) {}},
+		{EmptyClusterKeep, func(t *testing.T, m *Model) {
+			if got := m.Centroids(); got[1][0] != 100 || got[2][0] != 200 {
+				t.Errorf("empty centroids = %v, want them kept at [100] and [200]", got[1:])
+			}
+		}},
+	}
+	for _, tt := range tests {
+		m := fit(t, 3, data, initial, WithEmptyClusterStrategy(tt.strategy))
+		for i, c := range m.Centroids() {
+			if j := nonFinite(c); j >= 0 {
+				t.Errorf("strategy %d: centroid %d = %v", tt.strategy, i, c)
+			}
+		}
+		tt.check(t, m)
+	}
+}
+
+func TestZeroBasedLabels(t *testing.T) {
+	data := blobs(30)
+	m := fit(t, 3, data)
+	for j, c := range m.Centroids() {
+		if got := m.Predict(c); got != j {
+			t.Errorf("Predict(centroid %d) = %d", j, got)
+		}
+	}
+	for _, p := range (Dataset{{0, 0}, {10, 5}, {20, 10}}) {
+		if c := m.Predict(p); !slices.Equal(m.Cluster(c), m.Centroids()[c]) {
+			t.Errorf("Cluster(Predict(%v)) = %v, want centroid %v", p, m.Cluster(c), m.Centroids()[c])
+		}
+	}
+	for i, g := range m.Guesses() {
+		if g < 0 || g >= 3 {
+			t.Fatalf("Guesses()[%d] = %d, want in [0, 3)", i, g)
+		}
+	}
+}
+
+func TestFitDoesNotMutateData(t *testing.T) {
+	data := blobs(30)
+	want := make(Dataset, len(data))
+	for i := range data {
+		want[i] = slices.Clone(data[i])
+	}
+	options := map[string][]TrainerOption{
+		"lloyd":       nil,
+		"minibatch":   {WithMiniBatch(10)},
+		"macqueen":    {WithUpdateMode(MacQueen)},
+		"forgy":       {WithInit(Forgy)},
+		"parallel":    {WithParallelInit(2, 0)},
+		"greedy":      {WithGreedyInit(0)},
+		"elkan":       {WithElkan()},
+		"median":      {WithMedianUpdate()},
+		"spherical":   {WithSpherical()},
+		"emptyRandom": {WithEmptyClusterStrategy(EmptyClusterRandom)},
+	}
+	for name, opts := range options {
+		m := fit(t, 6, data, opts...)
+		if err := m.PartialFit(data[:10]); err != nil {
+			t.Fatal(err)
+		}
+		for i := range data {
+			if !slices.Equal(data[i], want[i]) {
+				t.Fatalf("%s: observation %d = %v after training, want %v", name, i, data[i], want[i])
+			}
+		}
+	}
+}
+
+func TestSeedDegenerateDatasets(t *testing.T) {
+	tests := []struct {
+		name string
+		k    int
+		data Dataset
+	}{
+		{"single observation", 1, Dataset{{3, 4}}},
+		{"identical observations", 3, Dataset{{1, 1}, {1, 1}, {1, 1}, {1, 1}}},
+		{"as many clusters as observations", 3, Dataset{{0}, {1}, {2}}},
+	}
+	for _, tt := range tests {
+		for seed := range int64(20) {
+			m, err := NewTrainer(tt.k, WithSeed(seed)).TryFit(tt.data)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			for i, c := range m.Centroids() {
+				if j := nonFinite(c); j >= 0 {
+					t.Fatalf("%s: centroid %d = %v", tt.name, i, c)
+				}
+			}
+		}
+	}
+}
+
+func TestSeedPicksLastObservation(t *testing.T) {
+	data := Dataset{{0}, {1}}
+	picked := false
+	for seed := range int64(20) {
+		m := &Model{data: data, k: 1, distanceFn: EuclideanDistance}
+		picked = picked || m.seed(rand.New(rand.NewSource(seed)))[0] == 1
+	}
+	if !picked {
+		t.Error("seeding never picked the last observation")
+	}
+}
+
+func TestChangesStableIterations(t *testing.T) {
+	// Observation 1 moves to the first cluster on the second iteration, then memberships are stable.
+	m := fit(t, 2, Dataset{{0}, {1}, {10}, {11}}, WithInitialCentroids(Dataset{{0}, {1}}), WithDeltaThreshold(0))
+	if m.Iter() != 3 || m.ConvergedReason() != ReasonChangesStable {
+		t.Errorf("Iter() = %d, ConvergedReason() = %v, want 3 and %v", m.Iter(), m.ConvergedReason(), ReasonChangesStable)
+	}
+	if got := m.Centroids(); got[0][0] != 0.5 || got[1][0] != 10.5 {
+		t.Errorf("Centroids() = %v, want [[0.5] [10.5]]", got)
+	}
+}
+
+func TestRaggedDataset(t *testing.T) {
+	_, err := NewTrainer(1).TryFit(Dataset{{0, 0}, {1}})
+	if !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("TryFit() error = %v, want %v", err, ErrDimensionMismatch)
+	}
+}
+
+func TestPredictDimensionMismatch(t *testing.T) {
+	m := fit(t, 2, blobs(10))
+	if _, err := m.PredictBatch(Dataset{{0, 0}, {1, 2, 3}}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("PredictBatch() error = %v, want %v", err, ErrDimensionMismatch)
+	}
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrDimensionMismatch) {
+			t.Errorf("Predict() panicked with %v, want %v", err, ErrDimensionMismatch)
+		}
+	}()
+	m.Predict([]float64{1})
+}
+
+func TestResetNotFitted(t *testing.T) {
+	data := blobs(10)
+	m := fit(t, 3, data)
+	m.Reset()
+	errs := map[string]error{}
+	_, errs["PredictBatch"] = m.PredictBatch(data)
+	_, errs["PredictBatchParallel"] = m.PredictBatchParallel(data, 2)
+	_, errs["ScoreSamples"] = m.ScoreSamples(data)
+	_, errs["PredictProba"] = m.PredictProba(data[0], 1)
+	_, errs["Transform"] = m.Transform(data)
+	_, errs["InverseTransform"] = m.InverseTransform([]int{0})
+	_, errs["QuantizationError"] = m.QuantizationError(data)
+	_, errs["OutlierThresholds"] = m.OutlierThresholds(0.5)
+	_, errs["DaviesBouldinIndex"] = m.DaviesBouldinIndex()
+	_, errs["SilhouetteScore"] = m.SilhouetteScore(0)
+	_, errs["CalinskiHarabaszScore"] = m.CalinskiHarabaszScore()
+	_, errs["DunnIndex"] = m.DunnIndex(0)
+	_, errs["MarshalBinary"] = m.MarshalBinary()
+	_, errs["Online"] = m.Online(context.Background(), nil)
+	errs["PartialFit"] = m.PartialFit(data)
+	errs["SplitCluster"] = m.SplitCluster(0)
+	errs["ContinueLearn"] = m.ContinueLearn(1)
+	for name, err := range errs {
+		if !errors.Is(err, ErrNotFitted) {
+			t.Errorf("%s() error = %v, want %v", name, err, ErrNotFitted)
+		}
+	}
+
+	m.Inertia()
+	m.BIC()
+	m.ClusterStats()
+	m.ClusterPoints(0)
+	m.ClusterEntropy()
+	m.Centroids()
+	m.Counts()
+	_ = m.String()
+	for name, fn := range map[string]func(){
+		"Predict":      func() { m.Predict(data[0]) },
+		"Cluster":      func() { m.Cluster(0) },
+		"DistanceTo":   func() { m.DistanceTo(data[0], 0) },
+		"NearestK":     func() { m.NearestK(data[0], 1) },
+		"AnomalyScore": func() { m.AnomalyScore(data[0]) },
+	} {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrNotFitted) {
+					t.Errorf("%s() panicked with %v, want %v", name, err, ErrNotFitted)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestEuclideanNormsMatchNaive(t *testing.T) {
+	// naive is EuclideanDistance hidden behind a closure, so the norms expansion is not used.
+	naive := WithDistanceFunc(func(a, b []float64) float64 { return EuclideanDistance(a, b) })
+	for _, offset := range []float64{0, 1e3, 1e9} {
+		var data Dataset
+		for _, p := range blobs(100)[:200] {
+			data = append(data, []float64{p[0] + offset, p[1] + offset})
+		}
+		want := fit(t, 2, data, naive)
+		got := fit(t, 2, data)
+		if !slices.Equal(got.Guesses(), want.Guesses()) || !slices.Equal(got.Sizes(), want.Sizes()) {
+			t.Errorf("offset %g: sizes = %v, want %v", offset, got.Sizes(), want.Sizes())
+		}
+		if r := got.Inertia() / want.Inertia(); math.Abs(r-1) > 1e-9 {
+			t.Errorf("offset %g: Inertia() = %v, want %v", offset, got.Inertia(), want.Inertia())
+		}
+	}
+}
+
+// assignSetup returns a model initialized on benchmarkData and the buffers to assign it.
+func assignSetup(t Trainer) (*Model, []float64, []float64, Dataset, *assignBuffers) {
+	data := benchmarkData(5000, 10)
+	m := &Model{data: data, k: t.k, distanceFn: t.distanceFn, distanceName: t.distanceName}
+	t.initialize(context.Background(), m, t.newRand())
+	cb, cn := prepare(t.k, len(data[0]))
+	return m, make([]float64, len(data)), cb, cn, t.newAssignBuffers(len(data), len(data[0]))
+}
+
+func TestAssignDoesNotAllocate(t *testing.T) {
+	for _, concurrency := range []int{1, 4} {
+		for _, distance := range []string{"EuclideanDistance", "ManhattanDistance"} {
+			tr := NewTrainer(20, WithSeed(1), WithConcurrency(concurrency), WithDistance(distance))
+			m, dist, cb, cn, buf := assignSetup(tr)
+			// Warm up the cached norms and the workers.
+			tr.assign(m, dist, cb, cn, buf)
+			if allocs := testing.AllocsPerRun(20, func() { tr.assign(m, dist, cb, cn, buf) }); allocs != 0 {
+				t.Errorf("concurrency %d, %s: assign allocated %v times per run", concurrency, distance, allocs)
+			}
+			buf.close()
+		}
+	}
+}
+
+func BenchmarkAssign(b *testing.B) {
+	tr := NewTrainer(20, WithSeed(1))
+	m, dist, cb, cn, buf := assignSetup(tr)
+	defer buf.close()
+	tr.assign(m, dist, cb, cn, buf)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		tr.assign(m, dist, cb, cn, buf)
+	}
+}
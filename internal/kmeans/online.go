@@ -0,0 +1,127 @@
+package kmeans
+
+import (
+	"context"
+	"slices"
+)
+
+// WithOnlineSchedule set the learning rate used by PartialFit and Online, given the number of observations
+// the cluster received including the current one. Default to 1/step (MacQueen).
+func WithOnlineSchedule(fn func(step int) float64) TrainerOption {
+	return func(t *Trainer) {
+		t.schedule = fn
+	}
+}
+
+// PartialFit update the centroids from a batch of observations, moving each observation nearest centroid
+// toward it with a learning rate of 1/count (MacQueen, see WithOnlineSchedule), where count is the number of observations the cluster received.
+// It can be called repeatedly as batches arrive, counts start from the training cluster sizes.
+// Guesses, Sizes and the quality metrics still describe the training data.
+func (m *Model) PartialFit(batch Dataset) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.validatePoints(batch); err != nil {
+		return err
+	}
+	m.initCounts()
+	for _, p := range batch {
+		c, _ := m.predict(p)
+		m.update(c, p)
+	}
+	return nil
+}
+
+// Event is emitted by Online for each processed observation.
+type Event struct {
+	// Cluster is the number of the cluster the observation was assigned to.
+	Cluster int
+	// Observation is the processed observation.
+	Observation []float64
+	// Distance is the distance between the observation and the cluster centroid before the update.
+	Distance float64
+	// PrevCentroid is a copy of the cluster centroid before the update.
+	PrevCentroid []float64
+}
+
+// Online update the centroids from a stream of observations like PartialFit, in a new goroutine,
+// emitting an Event for each processed observation.
+// The returned channel is closed once observations is closed and drained, when ctx is done, or when the model is Reset.
+// Observations with a wrong dimension or a non-finite value are skipped.
+// The lock is only held while updating a centroid, so predictions and Centroids can be used meanwhile.
+// It returns ErrNotFitted if the model has no centroids.
+func (m *Model) Online(ctx context.Context, observations <-chan []float64) (<-chan Event, error) {
+	m.mu.Lock()
+	if !m.fitted() {
+		m.mu.Unlock()
+		return nil, ErrNotFitted
+	}
+	m.initCounts()
+	l := len(m.centroids[0])
+	m.mu.Unlock()
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		for {
+			var (
+				o  []float64
+				ok bool
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case o, ok = <-observations:
+				if !ok {
+					return
+				}
+			}
+			if len(o) != l || nonFinite(o) >= 0 {
+				continue
+			}
+
+			m.mu.Lock()
+			if !m.fitted() || len(m.centroids[0]) != l {
+				m.mu.Unlock()
+				return
+			}
+			c, d := m.predict(o)
+			prev := slices.Clone(m.centroids[c])
+			m.update(c, o)
+			m.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case events <- Event{Cluster: c, Observation: o, Distance: d, PrevCentroid: prev}:
+			}
+		}
+	}()
+	return events, nil
+}
+
+func (m *Model) initCounts() {
+	if m.counts == nil {
+		m.counts = make([]int, m.k)
+		copy(m.counts, m.sizes)
+	}
+}
+
+// update move the centroid of cluster c toward p.
+func (m *Model) update(c int, p []float64) {
+	m.counts[c]++
+	eta := 1 / float64(m.counts[c])
+	if m.schedule != nil {
+		eta = m.schedule(m.counts[c])
+	}
+	centroid := m.centroids[c]
+	for j := range centroid {
+		centroid[j] += eta * (p[j] - centroid[j])
+	}
+}
+
+// Counts returns the number of observations each cluster received, from training and PartialFit.
+func (m *Model) Counts() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.initCounts()
+	return slices.Clone(m.counts)
+}
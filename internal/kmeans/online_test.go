@@ -0,0 +1,186 @@
+package kmeans
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentPredictWhileLearning(t *testing.T) {
+	data := blobs(50)
+	m := fit(t, 3, data)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			if err := m.PartialFit(data[:20]); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			m.Predict(data[0])
+			m.Centroids()
+			if g, s := m.Guesses(), m.Sizes(); len(g) != len(data) || len(s) != 3 {
+				t.Errorf("len(Guesses()) = %d, len(Sizes()) = %d", len(g), len(s))
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+func TestGuessesAndSizesAreCopies(t *testing.T) {
+	m := fit(t, 3, blobs(10))
+	m.Guesses()[0] = -1
+	m.Sizes()[0] = -1
+	if m.Guesses()[0] == -1 || m.Sizes()[0] == -1 {
+		t.Error("mutating the result of Guesses or Sizes changed the model")
+	}
+}
+
+func TestOnlineCancelReleasesLock(t *testing.T) {
+	data := blobs(20)
+	m := fit(t, 3, data)
+	ctx, cancel := context.WithCancel(context.Background())
+	observations := make(chan []float64)
+	events, err := m.Online(ctx, observations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	observations <- data[0]
+	<-events
+	cancel()
+	for range events {
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- m.PartialFit(data[:5])
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("PartialFit blocked after Online was cancelled")
+	}
+}
+
+func TestOnlineNotFitted(t *testing.T) {
+	reset := fit(t, 2, blobs(10))
+	reset.Reset()
+	for name, m := range map[string]*Model{"zero": {}, "reset": reset} {
+		if _, err := m.Online(context.Background(), make(chan []float64)); !errors.Is(err, ErrNotFitted) {
+			t.Errorf("%s: Online() error = %v, want %v", name, err, ErrNotFitted)
+		}
+	}
+}
+
+func TestOnlineStopsOnReset(t *testing.T) {
+	data := blobs(10)
+	m := fit(t, 2, data)
+	observations := make(chan []float64)
+	events, err := m.Online(context.Background(), observations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	observations <- data[0]
+	<-events
+	m.Reset()
+	observations <- data[1]
+	if _, ok := <-events; ok {
+		t.Error("Online kept emitting events after Reset")
+	}
+}
+
+func TestConcurrentReadsDuringOnline(t *testing.T) {
+	data := blobs(50)
+	m := fit(t, 3, data)
+	observations := make(chan []float64)
+	events, err := m.Online(context.Background(), observations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		defer close(observations)
+		for _, p := range data {
+			observations <- p
+		}
+	}()
+	go func() {
+		for range events {
+		}
+	}()
+	for range 20 {
+		m.Inertia()
+		if _, err := m.SilhouetteScore(20); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := m.CalinskiHarabaszScore(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := m.DunnIndex(20); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := m.DaviesBouldinIndex(); err != nil {
+			t.Fatal(err)
+		}
+		m.ClusterStats()
+		m.ClusterPoints(0)
+		m.ClusterEntropy()
+		m.Cluster(0)
+		m.Iter()
+		m.Shift()
+		m.ConvergedReason()
+		m.IsFitted()
+	}
+}
+
+func TestConcurrentBatchReadsDuringSplit(t *testing.T) {
+	data := blobs(50)
+	m := fit(t, 2, data)
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				_, _ = m.PredictBatch(data[:5])
+				_, _ = m.PredictBatchParallel(data[:5], 2)
+				_, _ = m.ScoreSamples(data[:5])
+				_, _ = m.PredictProba(data[0], 1)
+				_, _ = m.Transform(data[:5])
+				_, _ = m.QuantizationError(data[:5])
+			}
+		}()
+	}
+	for range 20 {
+		sizes := m.Sizes()
+		if err := m.SplitCluster(slices.Index(sizes, slices.Max(sizes)), WithSeed(1)); err != nil {
+			t.Error(err)
+		}
+		_ = m.PartialFit(data[:5])
+		time.Sleep(time.Millisecond)
+	}
+	m.Reset()
+	close(done)
+	wg.Wait()
+	if _, err := m.PredictBatch(data[:5]); !errors.Is(err, ErrNotFitted) {
+		t.Errorf("PredictBatch after Reset: error %v, expected ErrNotFitted", err)
+	}
+}
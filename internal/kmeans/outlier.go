@@ -0,0 +1,82 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// PredictOrOutlier returns number of cluster to which the observation would be assigned,
+// and whether the observation is farther than maxDist from that cluster centroid.
+// It panics like Predict.
+func (m *Model) PredictOrOutlier(p []float64, maxDist float64) (cluster int, isOutlier bool) {
+	c, d := m.PredictWithDistance(p)
+	return c, d > maxDist
+}
+
+// OutlierThresholds returns, for each cluster, the given quantile in [0, 1] of the distances between the
+// training observations and their centroid, to be used as the maxDist of PredictOrOutlier.
+// Clusters without observations get a threshold of 0.
+// It requires the training data, so it fails with ErrNotFitted on models that were loaded.
+func (m *Model) OutlierThresholds(quantile float64) ([]float64, error) {
+	if !(quantile >= 0 && quantile <= 1) {
+		return nil, fmt.Errorf("%w: got %v", ErrInvalidQuantile, quantile)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.data) == 0 || len(m.mapping) == 0 {
+		return nil, fmt.Errorf("%w: no training data", ErrNotFitted)
+	}
+
+	distances := make(Dataset, m.k)
+	for i, c := range m.mapping {
+		distances[c] = append(distances[c], m.distanceFn(m.data[i], m.centroids[c]))
+	}
+
+	thresholds := make([]float64, m.k)
+	for c, d := range distances {
+		if len(d) == 0 {
+			continue
+		}
+		slices.Sort(d)
+		thresholds[c] = d[int(math.Ceil(quantile*float64(len(d)-1)))]
+	}
+	return thresholds, nil
+}
+
+// AnomalyScore returns the distance between the observation and its nearest centroid divided by the mean distance
+// of that cluster training observations, the MeanDistance of ClusterStats, so scores are comparable across clusters
+// of different densities. Values well above 1 indicate anomalies.
+// Clusters without spread score 0 for observations on their centroid and +Inf otherwise.
+// It panics like Predict, or with ErrNotFitted on models without training data, such as loaded ones.
+func (m *Model) AnomalyScore(p []float64) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, d := m.predict(p)
+	if m.spreads == nil {
+		panic(fmt.Errorf("%w: no training data", ErrNotFitted))
+	}
+	if m.spreads[c] == 0 {
+		if d == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return d / m.spreads[c]
+}
+
+// spread returns the mean weighted distance between the training observations and their centroid, for each cluster.
+func (m *Model) spread() []float64 {
+	spreads := make([]float64, m.k)
+	total := make([]float64, m.k)
+	for i, c := range m.mapping {
+		spreads[c] += m.weight(i) * m.distanceFn(m.data[i], m.centroids[c])
+		total[c] += m.weight(i)
+	}
+	for c := range spreads {
+		if total[c] > 0 {
+			spreads[c] /= total[c]
+		}
+	}
+	return spreads
+}
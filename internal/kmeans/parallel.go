@@ -0,0 +1,93 @@
+package kmeans
+
+import "math/rand"
+
+// WithParallelInit initialize centroids using kmeans|| (Bahmani et al., 2012) instead of kmeans++:
+// each of the rounds samples about oversample candidates proportionally to their squared distance,
+// then the candidates, weighted by the number of observations they are nearest to, are reduced to k using kmeans++.
+// It needs far fewer passes over the data than kmeans++ for large k.
+// It falls back to kmeans++ when it cannot find more than k candidates.
+// This is a shorthand for WithInit(KMeansParallel) with custom rounds and oversample.
+func WithParallelInit(rounds, oversample int) TrainerOption {
+	return func(t *Trainer) {
+		t.init = KMeansParallel
+		t.parallelRounds = rounds
+		t.oversample = float64(oversample)
+	}
+}
+
+// seedParallel returns the indices of the observations picked as initial centroids by kmeans||.
+func (t Trainer) seedParallel(m *Model, rng *rand.Rand) []int {
+	distanceFn, squared := compareFn(m.distanceFn)
+	dist := func(a, b []float64) float64 {
+		d := distanceFn(a, b)
+		if !squared {
+			d *= d
+		}
+		return d
+	}
+
+	n := len(m.data)
+	candidates := []int{rng.Intn(n)}
+	d := make([]float64, n)
+	nearest := make([]int, n)
+	for i := range m.data {
+		d[i] = dist(m.data[i], m.data[candidates[0]])
+	}
+
+	rounds, oversample := t.parallelRounds, t.oversample
+	if rounds <= 0 {
+		rounds = 5
+	}
+	if oversample <= 0 {
+		oversample = 2 * float64(t.k)
+	}
+
+	workers := t.workers(n)
+	for range rounds {
+		phi := float64(0)
+		for i := range d {
+			phi += m.weight(i) * d[i]
+		}
+		if phi == 0 {
+			break
+		}
+
+		from := len(candidates)
+		for i := range d {
+			if rng.Float64()*phi < oversample*m.weight(i)*d[i] {
+				candidates = append(candidates, i)
+			}
+		}
+		added := candidates[from:]
+		runChunks(workers, n, func(_, lo, hi int) {
+			for i := lo; i < hi; i++ {
+				for j, c := range added {
+					if v := dist(m.data[i], m.data[c]); v < d[i] {
+						d[i] = v
+						nearest[i] = from + j
+					}
+				}
+			}
+		})
+	}
+
+	if len(candidates) <= t.k {
+		return m.seed(rng)
+	}
+
+	// Recluster the candidates weighted by the observations they are nearest to.
+	weights := make([]float64, len(candidates))
+	for i := range m.data {
+		weights[nearest[i]] += m.weight(i)
+	}
+	sub := Model{data: make(Dataset, len(candidates)), weights: weights, k: t.k, distanceFn: m.distanceFn}
+	for i, c := range candidates {
+		sub.data[i] = m.data[c]
+	}
+	seeds := sub.seed(rng)
+	for i := range seeds {
+		seeds[i] = candidates[seeds[i]]
+	}
+	return seeds
+}
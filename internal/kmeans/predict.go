@@ -0,0 +1,200 @@
+package kmeans
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// PredictBatch returns number of cluster to which each observation would be assigned.
+func (m *Model) PredictBatch(points Dataset) ([]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(points); err != nil {
+		return nil, err
+	}
+	labels := make([]int, len(points))
+	for i := range points {
+		labels[i], _ = m.predict(points[i])
+	}
+	return labels, nil
+}
+
+// PredictBatchParallel is PredictBatch splitting points across workers goroutines,
+// runtime.NumCPU() is a good value for CPU bound predictions, zero or less uses it.
+func (m *Model) PredictBatchParallel(points Dataset, workers int) ([]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(points); err != nil {
+		return nil, err
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	labels := make([]int, len(points))
+	runChunks(max(min(workers, len(points)), 1), len(points), func(_, from, to int) {
+		for i := from; i < to; i++ {
+			labels[i], _ = m.predict(points[i])
+		}
+	})
+	return labels, nil
+}
+
+// ScoreSamples returns the opposite of the distance between each observation and its nearest centroid,
+// so higher scores mean more typical observations.
+func (m *Model) ScoreSamples(points Dataset) ([]float64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(points); err != nil {
+		return nil, err
+	}
+	scores := make([]float64, len(points))
+	for i := range points {
+		_, d := m.predict(points[i])
+		scores[i] = -d
+	}
+	return scores, nil
+}
+
+// PredictProba returns the probability of the observation to belong to each cluster,
+// the softmax of the opposite of the distances to the centroids divided by temperature.
+// Lower temperatures give harder assignments.
+func (m *Model) PredictProba(p []float64, temperature float64) ([]float64, error) {
+	if !(temperature > 0) || math.IsInf(temperature, 0) {
+		return nil, fmt.Errorf("%w: got %v", ErrInvalidTemperature, temperature)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(Dataset{p}); err != nil {
+		return nil, err
+	}
+	proba := make([]float64, m.k)
+	for j := range m.k {
+		proba[j] = m.distanceFn(p, m.centroids[j])
+	}
+	// Shift by the nearest distance so the largest exponent is 0.
+	nearest := floats.Min(proba)
+	for j := range proba {
+		proba[j] = math.Exp(-(proba[j] - nearest) / temperature)
+	}
+	floats.Scale(1/floats.Sum(proba), proba)
+	return proba, nil
+}
+
+// validatePoints check that the model is fitted and that every observation is finite and has the same dimension as the centroids.
+// The caller must hold the lock.
+func (m *Model) validatePoints(points Dataset) error {
+	if !m.fitted() {
+		return ErrNotFitted
+	}
+	l := len(m.centroids[0])
+	for i := range points {
+		if len(points[i]) != l {
+			return fmt.Errorf("%w: observation %d has %d dimensions, expected %d", ErrDimensionMismatch, i, len(points[i]), l)
+		}
+		if j := nonFinite(points[i]); j >= 0 {
+			return fmt.Errorf("%w: observation %d has %v at dimension %d", ErrNonFinite, i, points[i][j], j)
+		}
+	}
+	return nil
+}
+
+// Transform returns the distance between each observation and each centroid,
+// entry [i][j] is the distance from observation i to cluster j.
+func (m *Model) Transform(points Dataset) (Dataset, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(points); err != nil {
+		return nil, err
+	}
+	out := make(Dataset, len(points))
+	for i := range points {
+		out[i] = make([]float64, m.k)
+		for j := range m.k {
+			out[i][j] = m.distanceFn(points[i], m.centroids[j])
+		}
+	}
+	return out, nil
+}
+
+// DistanceTo returns the distance between the observation and the centroid of cluster, in [0, k).
+// It panics like Predict, or with ErrInvalidClusters if cluster is out of range.
+func (m *Model) DistanceTo(p []float64, cluster int) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(Dataset{p}); err != nil {
+		panic(err)
+	}
+	if cluster < 0 || cluster >= m.k {
+		panic(fmt.Errorf("%w: cluster %d out of [0, %d)", ErrInvalidClusters, cluster, m.k))
+	}
+	return m.distanceFn(p, m.centroids[cluster])
+}
+
+// NearestK returns the n clusters nearest to the observation, nearest first, n is capped to k.
+// It keeps a bounded heap instead of sorting every centroid, in O(k·log(n)).
+// It panics like Predict.
+func (m *Model) NearestK(p []float64, n int) []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(Dataset{p}); err != nil {
+		panic(err)
+	}
+	n = max(min(n, m.k), 0)
+	h := make(farthestFirst, 0, n+1)
+	for c := range m.k {
+		d := m.distanceFn(p, m.centroids[c])
+		if len(h) < n {
+			heap.Push(&h, ranked{cluster: c, distance: d})
+		} else if n > 0 && d < h[0].distance {
+			h[0] = ranked{cluster: c, distance: d}
+			heap.Fix(&h, 0)
+		}
+	}
+
+	nearest := make([]int, len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		nearest[i] = heap.Pop(&h).(ranked).cluster
+	}
+	return nearest
+}
+
+type ranked struct {
+	cluster  int
+	distance float64
+}
+
+// farthestFirst is a max-heap of clusters by distance.
+type farthestFirst []ranked
+
+func (h farthestFirst) Len() int           { return len(h) }
+func (h farthestFirst) Less(i, j int) bool { return h[i].distance > h[j].distance }
+func (h farthestFirst) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *farthestFirst) Push(x any)        { *h = append(*h, x.(ranked)) }
+func (h *farthestFirst) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// InverseTransform returns a copy of the centroid of each label, an approximation of the observations they were predicted from.
+func (m *Model) InverseTransform(labels []int) (Dataset, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.fitted() {
+		return nil, ErrNotFitted
+	}
+	out := make(Dataset, len(labels))
+	for i, c := range labels {
+		if c < 0 || c >= m.k {
+			return nil, fmt.Errorf("%w: label %d is %d, expected [0, %d)", ErrInvalidClusters, i, c, m.k)
+		}
+		out[i] = slices.Clone(m.centroids[c])
+	}
+	return out, nil
+}
@@ -0,0 +1,49 @@
+package kmeans
+
+import (
+	"context"
+	"fmt"
+)
+
+// ContinueLearn run up to extraIterations more Lloyd iterations from the current centroids and assignments,
+// for example when the training stopped with ReasonMaxIterations, using the configuration of the Trainer that fitted the model.
+// Iter and ConvergedReason then describe the whole training, and the iteration callback numbers iterations from its start.
+// A seeded trainer derives the seed of each resume from the iterations run so far, so resumes do not replay the same random sequence.
+// It returns ErrNotFitted for models without training data, such as loaded ones,
+// and ErrNotResumable for models trained by other algorithms, such as BisectingTrainer.
+func (m *Model) ContinueLearn(extraIterations int) error {
+	return m.ContinueLearnContext(context.Background(), extraIterations)
+}
+
+// ContinueLearnContext is ContinueLearn stopping early when ctx is done.
+func (m *Model) ContinueLearnContext(ctx context.Context, extraIterations int) error {
+	if extraIterations < 1 {
+		return fmt.Errorf("%w: got %d", ErrZeroIterations, extraIterations)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.mapping) == 0 {
+		return fmt.Errorf("%w: no training data", ErrNotFitted)
+	}
+
+	// Models of other algorithms, such as bisecting ones, would be silently retrained by Lloyd.
+	if m.trainer == nil {
+		return fmt.Errorf("%w: not fitted by a Trainer", ErrNotResumable)
+	}
+	t := *m.trainer
+	t.k = m.k
+	t.distanceFn = m.distanceFn
+	t.distanceName = m.distanceName
+	t.maxIterations = extraIterations
+	if t.seeded {
+		t.seed += int64(m.iter)
+	}
+
+	iter, err := t.lloyd(ctx, m, t.newRand())
+	m.iter += iter
+	m.summarize()
+	if t.canonical {
+		m.canonicalize()
+	}
+	return err
+}
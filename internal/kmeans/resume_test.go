@@ -0,0 +1,100 @@
+package kmeans
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// slowStart starts the three blobs from centroids bunched in the first one, so the training needs several iterations.
+var slowStart = []TrainerOption{WithInitialCentroids(Dataset{{0, 0}, {0.1, 0}, {0.2, 0}}), WithDeltaThreshold(0), WithTolerance(0)}
+
+func TestContinueLearnMatchesFit(t *testing.T) {
+	data := blobs(30)
+	want := fit(t, 3, data, append(slowStart, WithMaxIterations(100))...)
+	if want.Iter() < 3 {
+		t.Fatalf("training converged in %d iterations, expected a slower start", want.Iter())
+	}
+
+	var numbers []int
+	callback := WithIterationCallback(func(iter int, _ float64, _ int) bool {
+		numbers = append(numbers, iter)
+		return true
+	})
+	m := fit(t, 3, data, append(slowStart, WithMaxIterations(1), callback)...)
+	if m.ConvergedReason() != ReasonMaxIterations {
+		t.Fatalf("reason %v, expected %v", m.ConvergedReason(), ReasonMaxIterations)
+	}
+	if err := m.ContinueLearn(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ContinueLearn(100); err != nil {
+		t.Fatal(err)
+	}
+	if m.Iter() != want.Iter() || m.ConvergedReason() != want.ConvergedReason() {
+		t.Errorf("resumed training ran %d iterations and stopped with %v, expected %d and %v", m.Iter(), m.ConvergedReason(), want.Iter(), want.ConvergedReason())
+	}
+	if !slices.EqualFunc(m.Centroids(), want.Centroids(), slices.Equal) {
+		t.Errorf("resumed centroids %v, expected %v", m.Centroids(), want.Centroids())
+	}
+	for i, n := range numbers {
+		if n != i+1 {
+			t.Fatalf("callback iteration numbers %v, expected 1 to %d", numbers, len(numbers))
+		}
+	}
+	if len(numbers) != want.Iter() {
+		t.Errorf("callback called %d times, expected %d", len(numbers), want.Iter())
+	}
+}
+
+func TestContinueLearnKeepsTrainerSeed(t *testing.T) {
+	m := fit(t, 3, blobs(10), WithMaxIterations(2))
+	seed := m.trainer.seed
+	if err := m.ContinueLearn(1); err != nil {
+		t.Fatal(err)
+	}
+	if m.trainer.seed != seed {
+		t.Errorf("resuming changed the model trainer seed from %d to %d", seed, m.trainer.seed)
+	}
+}
+
+func TestConcurrentReadsDuringResume(t *testing.T) {
+	data := blobs(100)
+	m := fit(t, 3, data, append(slowStart, WithMaxIterations(1))...)
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					_, _, _ = m.Guesses(), m.Sizes(), m.Iter()
+					_ = m.Predict(data[0])
+				}
+			}
+		}()
+	}
+	for range 5 {
+		if err := m.ContinueLearn(1); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := m.SplitCluster(0, WithSeed(1)); err != nil {
+		t.Error(err)
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestContinueLearnOtherAlgorithms(t *testing.T) {
+	data := blobs(20)
+	bisected := NewBisectingTrainer(3, SplitHighestSSE, WithSeed(1)).Fit(data)
+	if err := bisected.ContinueLearn(5); !errors.Is(err, ErrNotResumable) {
+		t.Errorf("ContinueLearn on a bisecting model: error %v, expected ErrNotResumable", err)
+	}
+}
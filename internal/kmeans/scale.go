@@ -0,0 +1,100 @@
+package kmeans
+
+import "math"
+
+// MinMaxScale rescale every column of data to [0, 1], returning the scaled copy and the per-column minimums and maximums.
+// Constant columns are scaled to 0.
+func MinMaxScale(data Dataset) (scaled Dataset, mins, maxs []float64) {
+	if len(data) == 0 {
+		return Dataset{}, nil, nil
+	}
+	l := len(data[0])
+	mins, maxs = make([]float64, l), make([]float64, l)
+	copy(mins, data[0])
+	copy(maxs, data[0])
+	for i := range data {
+		for j, v := range data[i] {
+			mins[j] = math.Min(mins[j], v)
+			maxs[j] = math.Max(maxs[j], v)
+		}
+	}
+
+	scaled = make(Dataset, len(data))
+	for i := range data {
+		scaled[i] = make([]float64, l)
+		for j, v := range data[i] {
+			if r := maxs[j] - mins[j]; r != 0 {
+				scaled[i][j] = (v - mins[j]) / r
+			}
+		}
+	}
+	return scaled, mins, maxs
+}
+
+// MinMaxUnscale map data scaled by MinMaxScale, such as centroids, back to the original units.
+func MinMaxUnscale(data Dataset, mins, maxs []float64) Dataset {
+	out := make(Dataset, len(data))
+	for i := range data {
+		out[i] = make([]float64, len(data[i]))
+		for j, v := range data[i] {
+			out[i][j] = mins[j] + v*(maxs[j]-mins[j])
+		}
+	}
+	return out
+}
+
+// StandardScale center every column of data to zero mean and unit variance,
+// returning the scaled copy and the per-column means and standard deviations.
+// Zero-variance columns are only centered.
+func StandardScale(data Dataset) (scaled Dataset, means, stds []float64) {
+	if len(data) == 0 {
+		return Dataset{}, nil, nil
+	}
+	l := len(data[0])
+	means, stds = make([]float64, l), make([]float64, l)
+	for i := range data {
+		for j, v := range data[i] {
+			means[j] += v
+		}
+	}
+	n := float64(len(data))
+	for j := range means {
+		means[j] /= n
+	}
+	for i := range data {
+		for j, v := range data[i] {
+			stds[j] += (v - means[j]) * (v - means[j])
+		}
+	}
+	for j := range stds {
+		stds[j] = math.Sqrt(stds[j] / n)
+	}
+
+	scaled = make(Dataset, len(data))
+	for i := range data {
+		scaled[i] = make([]float64, l)
+		for j, v := range data[i] {
+			scaled[i][j] = v - means[j]
+			if stds[j] != 0 {
+				scaled[i][j] /= stds[j]
+			}
+		}
+	}
+	return scaled, means, stds
+}
+
+// StandardUnscale map data scaled by StandardScale, such as centroids, back to the original units.
+func StandardUnscale(data Dataset, means, stds []float64) Dataset {
+	out := make(Dataset, len(data))
+	for i := range data {
+		out[i] = make([]float64, len(data[i]))
+		for j, v := range data[i] {
+			s := stds[j]
+			if s == 0 {
+				s = 1
+			}
+			out[i][j] = means[j] + v*s
+		}
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package kmeans
+
+import "fmt"
+
+// Elbow fit a model for each k from 2 to maxK using options and returns their inertia,
+// the inertia of k is at index k-2.
+func Elbow(data Dataset, maxK int, options ...TrainerOption) ([]float64, error) {
+	if maxK < 2 {
+		return nil, fmt.Errorf("%w: elbow requires maxK >= 2, got %d", ErrInvalidClusters, maxK)
+	}
+
+	inertia := make([]float64, 0, maxK-1)
+	for k := 2; k <= maxK; k++ {
+		m, err := NewTrainer(k, options...).TryFit(data)
+		if err != nil {
+			return inertia, err
+		}
+		inertia = append(inertia, m.Inertia())
+	}
+	return inertia, nil
+}
+
+// Scorer rates a fitted model, higher is better.
+type Scorer func(*Model) float64
+
+// autoKSilhouetteSample is the number of observations used to estimate the silhouette when no Scorer is given.
+const autoKSilhouetteSample = 1000
+
+// SilhouetteScorer returns a Scorer using SilhouetteScore estimated on sample observations.
+func SilhouetteScorer(sample int) Scorer {
+	return func(m *Model) float64 {
+		// The models rated by AutoK always have their training data.
+		s, _ := m.SilhouetteScore(sample)
+		return s
+	}
+}
+
+// AutoK fit a model for each k in [minK, maxK] using options and returns the k with the best score along with its model.
+// When scorer is nil, the silhouette estimated on a sample of observations is used.
+func AutoK(data Dataset, minK, maxK int, scorer Scorer, options ...TrainerOption) (int, *Model, error) {
+	if minK < 2 {
+		return 0, nil, fmt.Errorf("%w: auto k requires minK >= 2, got %d", ErrInvalidClusters, minK)
+	}
+	if maxK < minK || maxK > len(data) {
+		return 0, nil, fmt.Errorf("%w: auto k requires maxK in [%d, %d], got %d", ErrInvalidClusters, minK, len(data), maxK)
+	}
+	if scorer == nil {
+		scorer = SilhouetteScorer(autoKSilhouetteSample)
+	}
+
+	var (
+		best      *Model
+		bestK     int
+		bestScore float64
+	)
+	for k := minK; k <= maxK; k++ {
+		m, err := NewTrainer(k, options...).TryFit(data)
+		if err != nil {
+			return 0, nil, err
+		}
+		if s := scorer(m); best == nil || s > bestScore {
+			best, bestK, bestScore = m, k, s
+		}
+	}
+	return bestK, best, nil
+}
@@ -0,0 +1,339 @@
+package kmeans
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// SparseVector is a vector storing only its non-zero coordinates,
+// Values[i] is the coordinate at dimension Indices[i], with Indices strictly increasing.
+type SparseVector struct {
+	Indices []int
+	Values  []float64
+}
+
+// SparseDataset represents a set of sparse vectors.
+type SparseDataset []SparseVector
+
+// SparseEuclideanDistance is the euclidean distance between two sparse vectors, in O(nnz(a)+nnz(b)).
+func SparseEuclideanDistance(a, b SparseVector) float64 {
+	var (
+		s, t float64
+		i, j int
+	)
+	for i < len(a.Indices) || j < len(b.Indices) {
+		switch {
+		case j == len(b.Indices) || i < len(a.Indices) && a.Indices[i] < b.Indices[j]:
+			t = a.Values[i]
+			i++
+		case i == len(a.Indices) || b.Indices[j] < a.Indices[i]:
+			t = b.Values[j]
+			j++
+		default:
+			t = a.Values[i] - b.Values[j]
+			i++
+			j++
+		}
+		s += t * t
+	}
+	return math.Sqrt(s)
+}
+
+// dot returns the dot product of v with the dense vector c.
+func (v SparseVector) dot(c []float64) float64 {
+	s := float64(0)
+	for i, d := range v.Indices {
+		s += v.Values[i] * c[d]
+	}
+	return s
+}
+
+// squaredNorm returns the squared L2 norm of v.
+func (v SparseVector) squaredNorm() float64 {
+	return floats.Dot(v.Values, v.Values)
+}
+
+// dense returns v as a dense vector of the given dimension.
+func (v SparseVector) dense(dimension int) []float64 {
+	p := make([]float64, dimension)
+	for i, d := range v.Indices {
+		p[d] = v.Values[i]
+	}
+	return p
+}
+
+// SparseModel is a model trained on sparse vectors, it has dense centroids and predicts both sparse and dense observations.
+// It does not keep the training data as a Dataset, so the data based metrics and methods of *Model are not available.
+type SparseModel struct {
+	model *Model
+	data  SparseDataset
+}
+
+// FitSparse create and train the *SparseModel on sparse vectors of the given dimension.
+// Distances exploit the sparsity, costing O(nnz) per observation and centroid,
+// which requires the EuclideanDistance, the default: other distances fail with ErrUnknownDistance.
+// Initialization uses kmeans++, empty clusters keep their centroid, other options do not apply
+// except the seed, maximum iterations, delta threshold, tolerance and concurrency.
+// It panics if the dataset or the trainer configuration is invalid, use TryFitSparse to get an error instead.
+func (t Trainer) FitSparse(data SparseDataset, dimension int) *SparseModel {
+	m, err := t.TryFitSparse(data, dimension)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryFitSparse create and train the *SparseModel, returning an error if the dataset or the trainer configuration is invalid.
+func (t Trainer) TryFitSparse(data SparseDataset, dimension int) (*SparseModel, error) {
+	return t.FitSparseContext(context.Background(), data, dimension)
+}
+
+// FitSparseContext create and train the *SparseModel, stopping early when ctx is done.
+func (t Trainer) FitSparseContext(ctx context.Context, data SparseDataset, dimension int) (*SparseModel, error) {
+	if err := t.validateSparse(data, dimension); err != nil {
+		return nil, err
+	}
+	rng := t.newRand()
+	model := &Model{k: t.k, distanceFn: EuclideanDistance, distanceName: "EuclideanDistance", mapping: make([]int, len(data))}
+	sm := &SparseModel{model: model, data: data}
+
+	norms := make([]float64, len(data))
+	for i := range data {
+		norms[i] = data[i].squaredNorm()
+	}
+	model.centroids = make(Dataset, t.k)
+	for c, i := range sm.seed(norms, rng) {
+		model.centroids[c] = data[i].dense(dimension)
+	}
+
+	changeThreshold := int(float64(len(data)) * t.delta)
+	cb, cn := prepare(t.k, dimension)
+	_, previous := prepare(t.k, dimension)
+	buf := t.newAssignBuffers(len(data), dimension)
+	cnorms := make([]float64, t.k)
+	model.reason = ReasonMaxIterations
+	var err error
+	for ; model.iter < t.maxIterations; model.iter++ {
+		if err = ctx.Err(); err != nil {
+			model.reason = ReasonCancelled
+			break
+		}
+		for i := range previous {
+			copy(previous[i], model.centroids[i])
+		}
+		squaredNormsInto(cnorms, model.centroids)
+
+		runChunks(len(buf.changes), len(data), func(num, from, to int) {
+			cb, cn := buf.reset(num)
+			changes := 0
+			for i := from; i < to; i++ {
+				n, _ := sm.nearest(data[i], norms[i], cnorms)
+				if model.mapping[i] != n {
+					changes++
+				}
+				model.mapping[i] = n
+				cb[n]++
+				for j, d := range data[i].Indices {
+					cn[n][d] += data[i].Values[j]
+				}
+			}
+			buf.changes[num] = changes
+		})
+		changes := reduce(buf.cb, buf.cn, buf.changes, cb, cn)
+
+		for c := range cb {
+			if cb[c] > 0 {
+				floats.ScaleTo(model.centroids[c], 1/cb[c], cn[c])
+			}
+			cb[c] = 0
+			clear(cn[c])
+		}
+
+		model.shift = model.centroidShift(previous)
+		if changes == 0 || changes < changeThreshold {
+			model.reason = ReasonChangesStable
+			model.iter++
+			break
+		}
+		if model.shift < t.tolerance {
+			model.reason = ReasonToleranceMet
+			model.iter++
+			break
+		}
+	}
+
+	model.sizes = make([]int, t.k)
+	squaredNormsInto(cnorms, model.centroids)
+	for i := range data {
+		model.sizes[model.mapping[i]]++
+		_, d := sm.nearest(data[i], norms[i], cnorms)
+		model.inertia += d
+	}
+	return sm, err
+}
+
+func (t Trainer) validateSparse(data SparseDataset, dimension int) error {
+	if t.distanceName != "EuclideanDistance" {
+		return fmt.Errorf("%w: sparse training requires EuclideanDistance, got %q", ErrUnknownDistance, t.distanceName)
+	}
+	if t.maxIterations < 1 {
+		return fmt.Errorf("%w: got %d", ErrZeroIterations, t.maxIterations)
+	}
+	if t.k < 1 {
+		return fmt.Errorf("%w: got %d clusters", ErrInvalidClusters, t.k)
+	}
+	if len(data) == 0 {
+		return ErrEmptyDataset
+	}
+	if t.k > len(data) {
+		return fmt.Errorf("%w: %d clusters requested for %d observations", ErrInvalidClusters, t.k, len(data))
+	}
+	if dimension < 1 {
+		return fmt.Errorf("%w: observations must have at least one dimension", ErrDimensionMismatch)
+	}
+	for i, v := range data {
+		if len(v.Indices) != len(v.Values) {
+			return fmt.Errorf("%w: observation %d has %d indices for %d values", ErrDimensionMismatch, i, len(v.Indices), len(v.Values))
+		}
+		for j, d := range v.Indices {
+			if d < 0 || d >= dimension || j > 0 && d <= v.Indices[j-1] {
+				return fmt.Errorf("%w: observation %d has unsorted or out of range index %d", ErrDimensionMismatch, i, d)
+			}
+		}
+		if j := nonFinite(v.Values); j >= 0 {
+			return fmt.Errorf("%w: observation %d has %v at dimension %d", ErrNonFinite, i, v.Values[j], v.Indices[j])
+		}
+	}
+	return nil
+}
+
+// nearest returns the nearest centroid of v and the squared distance to it, from the squared norms of v and the centroids.
+func (sm *SparseModel) nearest(v SparseVector, norm float64, cnorms []float64) (int, float64) {
+	n, m := 0, math.Inf(1)
+	for c := range sm.model.centroids {
+		if d := norm + cnorms[c] - 2*v.dot(sm.model.centroids[c]); d < m {
+			n, m = c, d
+		}
+	}
+	return n, max(m, 0)
+}
+
+// seed returns the distinct indices of the observations picked as initial centroids by kmeans++.
+func (sm *SparseModel) seed(norms []float64, rng *rand.Rand) []int {
+	seeds := []int{rng.Intn(len(sm.data))}
+	d := make([]float64, len(sm.data))
+	for i := range d {
+		d[i] = math.Pow(SparseEuclideanDistance(sm.data[i], sm.data[seeds[0]]), 2)
+	}
+	for len(seeds) < sm.model.k {
+		s := floats.Sum(d)
+		next := -1
+		if s > 0 {
+			t := rng.Float64() * s
+			for i := range d {
+				if t -= d[i]; t <= 0 && d[i] > 0 {
+					next = i
+					break
+				}
+			}
+		}
+		if next < 0 {
+			// Every observation lies on a seed, or rounding, take the farthest remaining one.
+			next = floats.MaxIdx(d)
+			if d[next] == 0 {
+				for i := range d {
+					if !slices.Contains(seeds, i) {
+						next = i
+						break
+					}
+				}
+			}
+		}
+		seeds = append(seeds, next)
+		for i := range d {
+			d[i] = min(d[i], math.Pow(SparseEuclideanDistance(sm.data[i], sm.data[next]), 2))
+		}
+	}
+	return seeds
+}
+
+// PredictSparse returns number of cluster to which the sparse observation would be assigned, in [0, k).
+func (sm *SparseModel) PredictSparse(v SparseVector) int {
+	sm.model.mu.RLock()
+	defer sm.model.mu.RUnlock()
+	cnorms := squaredNorms(sm.model.centroids)
+	n, _ := sm.nearest(v, v.squaredNorm(), cnorms)
+	return n
+}
+
+// Predict returns number of cluster to which the dense observation would be assigned, in [0, k).
+func (sm *SparseModel) Predict(p []float64) int {
+	return sm.model.Predict(p)
+}
+
+// PredictWithDistance returns number of cluster to which the dense observation would be assigned
+// and the distance between the observation and that cluster centroid.
+func (sm *SparseModel) PredictWithDistance(p []float64) (int, float64) {
+	return sm.model.PredictWithDistance(p)
+}
+
+// PredictBatch returns number of cluster to which each dense observation would be assigned.
+func (sm *SparseModel) PredictBatch(points Dataset) ([]int, error) {
+	return sm.model.PredictBatch(points)
+}
+
+// IsFitted returns true if the model has centroids.
+func (sm *SparseModel) IsFitted() bool {
+	return sm.model.IsFitted()
+}
+
+// Guesses returns a copy of the mapping from data point indices to cluster numbers, in [0, k) like Predict.
+func (sm *SparseModel) Guesses() []int {
+	return sm.model.Guesses()
+}
+
+// Sizes returns a copy of the number of observations assigned to each cluster.
+func (sm *SparseModel) Sizes() []int {
+	return sm.model.Sizes()
+}
+
+// Cluster returns a copy of the dense centroid of cluster i.
+// It panics with ErrNotFitted if the model has no centroids.
+func (sm *SparseModel) Cluster(i int) []float64 {
+	return sm.model.Cluster(i)
+}
+
+// Centroids returns a copy of the dense centroids.
+func (sm *SparseModel) Centroids() Dataset {
+	return sm.model.Centroids()
+}
+
+// Inertia returns the sum of squared distances of the observations to their centroid, computed at the end of the training.
+func (sm *SparseModel) Inertia() float64 {
+	return sm.model.Inertia()
+}
+
+// Shift returns the maximum distance moved by a centroid during the last iteration.
+func (sm *SparseModel) Shift() float64 {
+	return sm.model.Shift()
+}
+
+// Iter returns model number of iterations run.
+func (sm *SparseModel) Iter() int {
+	return sm.model.Iter()
+}
+
+// ConvergedReason returns why the training stopped.
+func (sm *SparseModel) ConvergedReason() ConvergenceReason {
+	return sm.model.ConvergedReason()
+}
+
+// MarshalBinary encode the centroids of the model, it can be decoded into a *Model to predict dense observations.
+func (sm *SparseModel) MarshalBinary() ([]byte, error) {
+	return sm.model.MarshalBinary()
+}
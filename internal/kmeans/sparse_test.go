@@ -0,0 +1,82 @@
+package kmeans
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// sparseOf returns p as a sparse vector, dropping its zero coordinates.
+func sparseOf(p []float64) SparseVector {
+	var v SparseVector
+	for d, x := range p {
+		if x != 0 {
+			v.Indices = append(v.Indices, d)
+			v.Values = append(v.Values, x)
+		}
+	}
+	return v
+}
+
+func TestFitSparseMatchesDense(t *testing.T) {
+	data := blobs(30)
+	sparse := make(SparseDataset, len(data))
+	for i, p := range data {
+		sparse[i] = sparseOf(p)
+	}
+	sm, err := NewTrainer(3, WithSeed(1)).TryFitSparse(sparse, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sm.IsFitted() || len(sm.Centroids()) != 3 {
+		t.Fatalf("got %d centroids, expected 3", len(sm.Centroids()))
+	}
+	dense := fit(t, 3, data)
+	if ari, _ := AdjustedRandIndex(sm.Guesses(), dense.Guesses()); ari != 1 {
+		t.Errorf("adjusted rand index with the dense model %v, expected 1", ari)
+	}
+	if math.Abs(sm.Inertia()-dense.Inertia()) > 1e-6*dense.Inertia() {
+		t.Errorf("inertia %v, expected %v", sm.Inertia(), dense.Inertia())
+	}
+	for i, p := range data {
+		if sm.PredictSparse(sparse[i]) != sm.Predict(p) {
+			t.Fatalf("observation %d: sparse and dense predictions differ", i)
+		}
+	}
+	sizes := 0
+	for _, s := range sm.Sizes() {
+		sizes += s
+	}
+	if sizes != len(data) || sm.Iter() < 1 || sm.ConvergedReason() == ReasonMaxIterations {
+		t.Errorf("sizes sum %d, %d iterations, reason %v", sizes, sm.Iter(), sm.ConvergedReason())
+	}
+
+	b, err := sm.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m Model
+	if err := m.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if m.Predict(data[0]) != sm.Predict(data[0]) {
+		t.Error("decoded model predicts differently")
+	}
+}
+
+func TestFitSparseDistance(t *testing.T) {
+	data := SparseDataset{sparseOf([]float64{1, 0}), sparseOf([]float64{0, 1}), sparseOf([]float64{5, 5})}
+	if _, err := NewTrainer(2, WithDistance("ManhattanDistance")).TryFitSparse(data, 2); !errors.Is(err, ErrUnknownDistance) {
+		t.Errorf("sparse training with ManhattanDistance: error %v, expected ErrUnknownDistance", err)
+	}
+	if _, err := NewTrainer(2, WithDistanceFunc(EuclideanDistance)).FitSparseContext(context.Background(), data, 2); err != nil {
+		t.Errorf("sparse training with EuclideanDistance: %v", err)
+	}
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrDimensionMismatch) {
+			t.Errorf("FitSparse panicked with %v, expected ErrDimensionMismatch", err)
+		}
+	}()
+	NewTrainer(2).FitSparse(data, 1)
+}
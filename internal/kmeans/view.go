@@ -0,0 +1,106 @@
+package kmeans
+
+// view exposes the read-only methods of a *Model, for the models of other algorithms built on one,
+// whose extra state would be left stale by the methods training or modifying the *Model.
+type view struct {
+	model *Model
+}
+
+// IsFitted returns true if the model has centroids.
+func (v view) IsFitted() bool {
+	return v.model.IsFitted()
+}
+
+// Predict returns number of cluster to which the observation would be assigned, in [0, k).
+// It panics like Model.Predict.
+func (v view) Predict(p []float64) int {
+	return v.model.Predict(p)
+}
+
+// PredictWithDistance returns number of cluster to which the observation would be assigned
+// and the distance between the observation and that cluster centroid.
+func (v view) PredictWithDistance(p []float64) (int, float64) {
+	return v.model.PredictWithDistance(p)
+}
+
+// PredictBatch returns number of cluster to which each observation would be assigned.
+func (v view) PredictBatch(points Dataset) ([]int, error) {
+	return v.model.PredictBatch(points)
+}
+
+// Transform returns the distance between each observation and each centroid,
+// entry [i][j] is the distance from observation i to cluster j.
+func (v view) Transform(points Dataset) (Dataset, error) {
+	return v.model.Transform(points)
+}
+
+// Guesses returns a copy of the mapping from data point indices to cluster numbers, in [0, k) like Predict.
+func (v view) Guesses() []int {
+	return v.model.Guesses()
+}
+
+// Sizes returns a copy of the number of observations assigned to each cluster.
+func (v view) Sizes() []int {
+	return v.model.Sizes()
+}
+
+// Cluster returns a copy of the centroid of cluster i, using the same numbering as Predict and Guesses.
+// It panics with ErrNotFitted if the model has no centroids.
+func (v view) Cluster(i int) []float64 {
+	return v.model.Cluster(i)
+}
+
+// Centroids returns a copy of the centroids.
+func (v view) Centroids() Dataset {
+	return v.model.Centroids()
+}
+
+// Inertia returns the sum of squared distances of the training observations to their centroid.
+func (v view) Inertia() float64 {
+	return v.model.Inertia()
+}
+
+// Iter returns model number of iterations run.
+func (v view) Iter() int {
+	return v.model.Iter()
+}
+
+// ConvergedReason returns why the training stopped.
+func (v view) ConvergedReason() ConvergenceReason {
+	return v.model.ConvergedReason()
+}
+
+// ClusterStats returns the spread of each cluster, indexed by cluster.
+func (v view) ClusterStats() []ClusterStat {
+	return v.model.ClusterStats()
+}
+
+// ClusterIndices returns the indices in the training dataset of the observations assigned to cluster.
+func (v view) ClusterIndices(cluster int) []int {
+	return v.model.ClusterIndices(cluster)
+}
+
+// ClusterPoints returns a copy of the training observations assigned to cluster.
+func (v view) ClusterPoints(cluster int) Dataset {
+	return v.model.ClusterPoints(cluster)
+}
+
+// SilhouetteScore returns the mean silhouette coefficient of the training observations, see Model.SilhouetteScore.
+func (v view) SilhouetteScore(sample int) (float64, error) {
+	return v.model.SilhouetteScore(sample)
+}
+
+// DaviesBouldinIndex returns the Davies-Bouldin index of the training observations, see Model.DaviesBouldinIndex.
+func (v view) DaviesBouldinIndex() (float64, error) {
+	return v.model.DaviesBouldinIndex()
+}
+
+// CalinskiHarabaszScore returns the Calinski-Harabasz score of the training observations, see Model.CalinskiHarabaszScore.
+func (v view) CalinskiHarabaszScore() (float64, error) {
+	return v.model.CalinskiHarabaszScore()
+}
+
+// DunnIndex returns the Dunn index of the training observations, see Model.DunnIndex.
+func (v view) DunnIndex(sample int) (float64, error) {
+	return v.model.DunnIndex(sample)
+}
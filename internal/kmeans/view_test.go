@@ -0,0 +1,30 @@
+package kmeans
+
+import "testing"
+
+func TestViewHidesMutators(t *testing.T) {
+	data := blobs(10)
+	models := map[string]any{
+		"FuzzyModel":   NewFuzzyTrainer(3, 2, WithSeed(1)).Fit(data),
+		"MedoidsModel": NewMedoidsTrainer(3, WithSeed(1)).Fit(data),
+	}
+	for name, m := range models {
+		if _, ok := m.(interface {
+			SplitCluster(int, ...TrainerOption) error
+		}); ok {
+			t.Errorf("%s exposes SplitCluster", name)
+		}
+		if _, ok := m.(interface{ ContinueLearn(int) error }); ok {
+			t.Errorf("%s exposes ContinueLearn", name)
+		}
+		if _, ok := m.(interface{ PartialFit(Dataset) error }); ok {
+			t.Errorf("%s exposes PartialFit", name)
+		}
+		if _, ok := m.(interface{ Reset() }); ok {
+			t.Errorf("%s exposes Reset", name)
+		}
+		if v, ok := m.(interface{ Sizes() []int }); !ok || len(v.Sizes()) != 3 {
+			t.Errorf("%s does not expose the sizes of its 3 clusters", name)
+		}
+	}
+}
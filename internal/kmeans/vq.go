@@ -0,0 +1,31 @@
+package kmeans
+
+// Encode returns the code of each observation, the number of its nearest centroid, using the centroids as a codebook.
+// It is the same as PredictBatch.
+func (m *Model) Encode(points Dataset) ([]int, error) {
+	return m.PredictBatch(points)
+}
+
+// Decode returns the codebook entry of each code, the same as InverseTransform.
+func (m *Model) Decode(codes []int) (Dataset, error) {
+	return m.InverseTransform(codes)
+}
+
+// QuantizationError returns the mean squared euclidean distance between each observation and its nearest centroid,
+// the reconstruction error of encoding then decoding points. It is 0 when there is no observation.
+func (m *Model) QuantizationError(points Dataset) (float64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.validatePoints(points); err != nil {
+		return 0, err
+	}
+	if len(points) == 0 {
+		return 0, nil
+	}
+	s := float64(0)
+	for _, p := range points {
+		c, _ := m.predict(p)
+		s += EuclideanDistanceSquared(p, m.centroids[c])
+	}
+	return s / float64(len(points)), nil
+}
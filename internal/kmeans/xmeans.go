@@ -0,0 +1,163 @@
+package kmeans
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// BIC returns the Bayesian information criterion of the model on the training data under the identical spherical
+// Gaussian assumption of X-means (Pelleg and Moore, 2000), higher is better. Observation weights are ignored.
+func (m *Model) BIC() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return bic(m.data, m.mapping, m.centroids)
+}
+
+func bic(data Dataset, mapping []int, centroids Dataset) float64 {
+	r, k := float64(len(data)), float64(len(centroids))
+	if len(data) == 0 {
+		return math.Inf(-1)
+	}
+	dimension := float64(len(data[0]))
+
+	sizes := make([]float64, len(centroids))
+	sse := float64(0)
+	for i, c := range mapping {
+		sizes[c]++
+		sse += EuclideanDistanceSquared(data[i], centroids[c])
+	}
+	if r <= k {
+		return math.Inf(-1)
+	}
+	variance := sse / (r - k)
+	if variance == 0 {
+		// Every observation lies on its centroid, the likelihood is unbounded.
+		return math.Inf(1)
+	}
+
+	likelihood := float64(0)
+	for _, n := range sizes {
+		if n == 0 {
+			continue
+		}
+		likelihood += n*math.Log(n) - n*math.Log(r) -
+			n/2*math.Log(2*math.Pi) - n*dimension/2*math.Log(variance) - (n-k)/2
+	}
+	params := (k - 1) + dimension*k + 1
+	return likelihood - params/2*math.Log(r)
+}
+
+// XMeansTrainer train models using X-means: starting from minK clusters, each cluster is split in two with a 2-means run
+// when it improves the BIC of that cluster, then the whole model is refined, until no split helps, the refined model
+// does not improve the overall BIC, or maxK is reached.
+type XMeansTrainer struct {
+	Trainer
+	maxK int
+}
+
+// NewXMeansTrainer create new XMeansTrainer searching between minK and maxK clusters,
+// options configure the initial, split and refinement runs.
+func NewXMeansTrainer(minK, maxK int, options ...TrainerOption) XMeansTrainer {
+	return XMeansTrainer{Trainer: NewTrainer(minK, options...), maxK: maxK}
+}
+
+// Fit create and train the *Model.
+// It panics if the dataset or the trainer configuration is invalid, use TryFit to get an error instead.
+func (t XMeansTrainer) Fit(data Dataset) *Model {
+	m, err := t.TryFit(data)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// TryFit create and train the *Model, returning an error if the dataset or the trainer configuration is invalid.
+func (t XMeansTrainer) TryFit(data Dataset) (*Model, error) {
+	return t.FitContext(context.Background(), data)
+}
+
+// FitContext create and train the *Model, stopping early when ctx is done.
+func (t XMeansTrainer) FitContext(ctx context.Context, data Dataset) (*Model, error) {
+	if err := t.validate(data); err != nil {
+		return nil, err
+	}
+	if t.maxK < t.k || t.maxK > len(data) {
+		return nil, fmt.Errorf("%w: x-means requires maxK in [%d, %d], got %d", ErrInvalidClusters, t.k, len(data), t.maxK)
+	}
+	model, err := t.Trainer.FitContext(ctx, data)
+	if err != nil {
+		return model, err
+	}
+
+	best := bic(model.data, model.mapping, model.centroids)
+	split := t.Trainer
+	split.k = 2
+	split.initial = nil
+	for model.k < t.maxK {
+		type candidate struct {
+			cluster   int
+			gain      float64
+			centroids Dataset
+		}
+		var candidates []candidate
+		for c := range model.k {
+			sub := Dataset{}
+			for i := range model.data {
+				if model.mapping[i] == c {
+					sub = append(sub, model.data[i])
+				}
+			}
+			if len(sub) < 2 {
+				continue
+			}
+			if split.seeded {
+				split.seed++
+			}
+			m, err := split.FitContext(ctx, sub)
+			if err != nil {
+				return model, err
+			}
+			parent := bic(sub, make([]int, len(sub)), Dataset{model.centroids[c]})
+			if gain := bic(sub, m.mapping, m.centroids) - parent; gain > 0 {
+				candidates = append(candidates, candidate{cluster: c, gain: gain, centroids: m.centroids})
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		// Keep the most improving splits within maxK.
+		slices.SortFunc(candidates, func(a, b candidate) int {
+			return cmp.Compare(b.gain, a.gain)
+		})
+		candidates = candidates[:min(len(candidates), t.maxK-model.k)]
+
+		// The local gains may not add up, so refine with the most improving splits only, one more at a time,
+		// and keep the model with the best overall BIC.
+		var next *Model
+		score := best
+		centroids := model.Centroids()
+		for _, s := range candidates {
+			centroids[s.cluster] = s.centroids[0]
+			centroids = append(centroids, s.centroids[1])
+			refine := t.Trainer
+			refine.k = len(centroids)
+			refine.initial = centroids
+			refined, err := refine.fit(ctx, model.data, nil)
+			if err != nil {
+				return model, err
+			}
+			if b := bic(refined.data, refined.mapping, refined.centroids); b > score {
+				next, score = refined, b
+			}
+		}
+		if next == nil {
+			break
+		}
+		model, best = next, score
+	}
+	return model, nil
+}
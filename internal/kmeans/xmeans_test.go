@@ -0,0 +1,19 @@
+package kmeans
+
+import "testing"
+
+func TestXMeansFindsBlobs(t *testing.T) {
+	data := blobs(50)
+	for minK := 1; minK <= 3; minK++ {
+		m, err := NewXMeansTrainer(minK, 10, WithSeed(1)).TryFit(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(m.Centroids()) != 3 {
+			t.Errorf("minK %d: x-means found %d clusters with sizes %v, expected 3", minK, len(m.Centroids()), m.Sizes())
+		}
+		if three := fit(t, 3, data); m.BIC() < three.BIC() {
+			t.Errorf("minK %d: BIC %v, expected at least the 3-means BIC %v", minK, m.BIC(), three.BIC())
+		}
+	}
+}